@@ -10,90 +10,93 @@ import (
 	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/agnivo988/Repo-lyzer/internal/github"
 	"github.com/agnivo988/Repo-lyzer/internal/output"
+	"github.com/agnivo988/Repo-lyzer/internal/report"
 )
 
-// RunAnalyze executes the analyze command for a given GitHub repository.
-// It takes the owner and repository name, performs comprehensive analysis including
-// repository info, languages, commits, contributors, and generates various reports.
-// Parameters:
-//   - owner: GitHub username or organization name
-//   - repo: Repository name
-// Returns an error if the analysis fails.
-func RunAnalyze(owner, repo string) error {
-	args := []string{owner + "/" + repo}
-	analyzeCmd.SetArgs(args)
-	return analyzeCmd.Execute()
-}
-
-
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze owner/repo",
 	Short: "Analyze a GitHub repository",
+	Long: "Analyze a GitHub repository and print a report.\n\n" +
+		"Formats:\n" +
+		"  text      human-readable report (default)\n" +
+		"  json      full report, schema " + report.SchemaVersion + "\n" +
+		"  yaml      the json report as YAML\n" +
+		"  csv       the report flattened to field,value rows\n" +
+		"  table     the flattened report as a table\n" +
+		"  markdown  the report as a Markdown document\n" +
+		"  sarif     security findings as SARIF 2.1.0",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		parts := strings.Split(args[0], "/")
 		if len(parts) != 2 {
 			return fmt.Errorf("repository must be in owner/repo format")
 		}
-
-		client := github.NewClient()
-		repo, err := client.GetRepo(parts[0], parts[1])
-		if err != nil {
+		if err := checkFormat(append([]string{"text", "sarif"}, report.Formats...)...); err != nil {
 			return err
 		}
-
-		langs, err := client.GetLanguages(parts[0], parts[1])
+		model, err := healthModel()
 		if err != nil {
-			return fmt.Errorf("failed to get languages: %w", err)
+			return err
 		}
 
-		commits, err := client.GetCommits(parts[0], parts[1], 365)
+		pipeline := github.NewPipeline(newClient(), github.DefaultWorkers)
+		data, err := pipeline.Fetch(cmd.Context(), parts[0], parts[1], fetchOptions())
 		if err != nil {
-			return fmt.Errorf("failed to get commits: %w", err)
+			return err
 		}
+		// Failed fetches are reported as warnings, see Analysis.DataWarnings
+		a := analyzer.Analyze(data, days, model)
+		repo := a.Repo
 
-		_, err = client.GetFileTree(parts[0], parts[1], repo.DefaultBranch)
-		if err != nil {
-			return fmt.Errorf("failed to get file tree: %w", err)
+		switch format {
+		case "text":
+		case "sarif":
+			// SARIF has no place for them, so data warnings go to stderr
+			for _, w := range a.DataWarnings() {
+				fmt.Fprintln(cmd.ErrOrStderr(), "warning:", w)
+			}
+			return output.WriteSARIF(cmd.OutOrStdout(), output.SARIFTarget{
+				URL:    repo.HTMLURL,
+				Branch: repo.DefaultBranch,
+			}, a.Security)
+		default:
+			return report.Write(cmd.OutOrStdout(), report.Build(a), format)
 		}
-         
-		
-		score := analyzer.CalculateHealth(repo, commits)
-		activity := analyzer.CommitsPerDay(commits)
-		contributors, err := client.GetContributors(parts[0], parts[1])
-            if err != nil {
-	              return err
-                     }
-
-					 busFactor, busRisk := analyzer.BusFactor(contributors)
-
-		maturityScore, maturityLevel :=
-			analyzer.RepoMaturityScore(
-				repo,
-				len(commits),
-				len(contributors),
-				false,
-			)
 
 		summary := analyzer.BuildRecruiterSummary(
 			repo.FullName,
 			repo.Forks,
 		    repo.Stars,
-			len(commits),
-			len(contributors),
-			maturityScore,
-			maturityLevel,
-			busFactor,
-			busRisk,
+			len(a.Commits),
+			len(a.Contributors),
+			a.MaturityScore,
+			a.MaturityLevel,
+			a.BusFactor.Count,
+			a.BusFactor.Risk,
 		)
+		summary.PRHealth = a.PullRequests.Health
+		summary.IssueHealth = a.Issues.Health
+		summary.Trend = a.Trend.String()
+		summary.Days = a.Days
 
 		output.PrintRepo(repo)
-		output.PrintLanguages(langs)
-		output.PrintCommitActivity(activity,14)
-		output.PrintHealth(score)
-		output.PrintGitHubAPIStatus(client)
+		output.PrintDataWarnings(a.DataWarnings())
+		output.PrintLanguages(a.Languages)
+		output.PrintCommitActivity(analyzer.CommitsPerDay(a.Commits),14)
+		output.PrintActivityTrend(a.Trend)
+		output.PrintBusFactor(a.BusFactor)
+		output.PrintReleases(a.Releases)
+		output.PrintFiles(a.Files)
+		output.PrintSecurity(a.Security)
+		output.PrintHealth(a.HealthScore)
+		output.PrintHealthBreakdown(a.Health)
+		output.PrintGitHubAPIStatus(pipeline.Client())
 		output.PrintRecruiterSummary(summary)
 
 		return nil
 	},
 }
+
+func init() {
+	rootCmd.AddCommand(analyzeCmd)
+}
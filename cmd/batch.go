@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+	"github.com/agnivo988/Repo-lyzer/internal/output"
+	"github.com/agnivo988/Repo-lyzer/internal/report"
+)
+
+var (
+	batchDir         string
+	batchConcurrency int
+	batchReserve     int
+	batchFresh       bool
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch repos.txt",
+	Short: "Analyze every repository listed in a file",
+	Long: "Analyze every repository listed in a file, one owner/repo (or GitHub URL) per line;\n" +
+		"blank lines and lines starting with # are skipped.\n\n" +
+		"Each repository's report is saved as JSON in the output directory as soon as it\n" +
+		"is done, and the combined summary.csv and summary.json are written at the end.\n" +
+		"Repositories with a complete saved report for the same window are skipped, so\n" +
+		"an interrupted or partly failed batch resumes where it stopped when run again;\n" +
+		"--fresh analyzes everything again.\n\n" +
+		"All repositories share one client and worker pool. Before starting a repository\n" +
+		"the batch waits for the rate limit to reset when fewer than --reserve requests\n" +
+		"are left.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkFormat("text"); err != nil {
+			return err
+		}
+		if batchConcurrency < 1 {
+			return fmt.Errorf("--concurrency must be positive, got %d", batchConcurrency)
+		}
+		repos, err := readRepoList(args[0])
+		if err != nil {
+			return err
+		}
+		model, err := healthModel()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(batchDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		ctx := cmd.Context()
+		pipeline := github.NewPipeline(newClient(), github.DefaultWorkers)
+		batch := report.NewBatch(days)
+		reports := make([]*report.Report, len(repos))
+		failures := make([]error, len(repos))
+
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex // Guards done, reports and failures
+			done  int
+			slots = make(chan struct{}, batchConcurrency)
+		)
+		progress := func(repo, status string) {
+			done++
+			fmt.Printf("[%d/%d] %s %s\n", done, len(repos), repo, status)
+		}
+
+		for i, repo := range repos {
+			path := report.ExportPath(batchDir, repo)
+			if !batchFresh {
+				// Reports with warnings are analyzed again to fill in the missing data
+				if r, err := report.Load(path); err == nil && r.WindowDays == days && len(r.Warnings) == 0 {
+					mu.Lock()
+					reports[i] = &r
+					progress(repo, output.SuccessStyle.Render("✓ saved report"))
+					mu.Unlock()
+					continue
+				}
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				break
+			}
+
+			if err := waitForReserve(ctx, pipeline.Client(), batchReserve); err != nil {
+				<-slots
+				break
+			}
+
+			wg.Add(1)
+			go func(i int, repo, path string) {
+				defer wg.Done()
+				defer func() { <-slots }()
+
+				r, err := analyzeForBatch(ctx, pipeline, repo, model)
+				if err == nil {
+					err = report.Save(path, r)
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					failures[i] = err
+					progress(repo, output.ErrorStyle.Render("✗ "+err.Error()))
+					return
+				}
+				reports[i] = &r
+				status := output.SuccessStyle.Render(fmt.Sprintf("✓ health %s, bus factor %s",
+					fmt.Sprint(r.Metrics.HealthScore), report.FormatValue(r.Metrics.BusFactor)))
+				if len(r.Warnings) > 0 {
+					status += output.WarningStyle.Render(fmt.Sprintf(" ⚠ %d metrics without data", len(r.Warnings)))
+				}
+				progress(repo, status)
+			}(i, repo, path)
+		}
+		wg.Wait()
+
+		pending := 0
+		for i, repo := range repos {
+			switch {
+			case reports[i] != nil:
+				batch.Reports = append(batch.Reports, *reports[i])
+			case failures[i] != nil && ctx.Err() == nil:
+				batch.Failures = append(batch.Failures, report.Failure{Repository: repo, Error: failures[i].Error()})
+			default:
+				pending++
+			}
+		}
+		if err := writeBatch(batch); err != nil {
+			return err
+		}
+
+		fmt.Println(output.SectionStyle.Render("\n📦 Batch Summary"))
+		fmt.Printf("Analyzed    : %d of %d\n", len(batch.Reports), len(repos))
+		fmt.Printf("Failed      : %d\n", len(batch.Failures))
+		fmt.Printf("Reports     : %s\n", batchDir)
+
+		if ctx.Err() != nil || pending > 0 {
+			return fmt.Errorf("batch stopped with %d repositories left; run it again to resume", pending+len(batch.Failures))
+		}
+		if len(batch.Failures) > 0 {
+			return fmt.Errorf("%d of %d repositories failed; run the batch again to retry them", len(batch.Failures), len(repos))
+		}
+		return nil
+	},
+}
+
+// readRepoList reads owner/repo names from a list file, skipping blank
+// lines, comments and duplicates.
+func readRepoList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repository list: %w", err)
+	}
+	defer f.Close()
+
+	var repos []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+		name = strings.TrimPrefix(name, "https://")
+		name = strings.TrimPrefix(name, "github.com/")
+		name = strings.TrimSuffix(strings.TrimSuffix(name, "/"), ".git")
+		if parts := strings.Split(name, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("%s:%d: %q is not in owner/repo format", path, line, scanner.Text())
+		}
+		if !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			repos = append(repos, name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read repository list: %w", err)
+	}
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("%s lists no repositories", path)
+	}
+	return repos, nil
+}
+
+// analyzeForBatch fetches and analyzes one repository of a batch. It only
+// fails when the repository itself can't be fetched or ctx is done, so a
+// canceled analysis is never saved.
+func analyzeForBatch(ctx context.Context, pipeline *github.Pipeline, name string, model analyzer.HealthModel) (report.Report, error) {
+	owner, repo, _ := strings.Cut(name, "/")
+	data, err := pipeline.Fetch(ctx, owner, repo, fetchOptions())
+	if err != nil {
+		return report.Report{}, err
+	}
+	// Failed fetches are kept as the report's warnings
+	return report.Build(analyzer.Analyze(data, days, model)), nil
+}
+
+// waitForReserve waits for the core rate limit to reset when fewer than
+// reserve requests are left, so that the repositories in flight can
+// finish before the budget runs out.
+func waitForReserve(ctx context.Context, client *github.Client, reserve int) error {
+	budget, ok := client.RateBudget()
+	if !ok || budget.Remaining >= reserve || !time.Now().Before(budget.Reset) {
+		return nil
+	}
+
+	fmt.Println(output.WarningStyle.Render(fmt.Sprintf("⏳ %d API requests left; waiting for the rate limit to reset at %s",
+		budget.Remaining, budget.Reset.Format("15:04:05"))))
+	t := time.NewTimer(time.Until(budget.Reset) + time.Second)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// writeBatch writes the combined reports to the batch directory.
+func writeBatch(batch report.Batch) error {
+	for name, write := range map[string]func(*os.File) error{
+		"summary.json": func(f *os.File) error { return batch.WriteJSON(f) },
+		"summary.csv":  func(f *os.File) error { return batch.WriteCSV(f) },
+	} {
+		f, err := os.Create(filepath.Join(batchDir, name))
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if err := write(f); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	batchCmd.Flags().StringVar(&batchDir, "dir", "repo-lyzer-batch", "directory for the per-repository reports and the summaries")
+	batchCmd.Flags().IntVar(&batchConcurrency, "concurrency", 4, "repositories analyzed at the same time")
+	batchCmd.Flags().IntVar(&batchReserve, "reserve", 200, "API requests to keep in reserve before starting another repository")
+	batchCmd.Flags().BoolVar(&batchFresh, "fresh", false, "analyze every repository again instead of resuming")
+	rootCmd.AddCommand(batchCmd)
+}
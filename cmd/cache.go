@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+	"github.com/agnivo988/Repo-lyzer/internal/output"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the on-disk GitHub API response cache",
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete all cached API responses",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache := github.DefaultCache()
+		if cache == nil {
+			return fmt.Errorf("the response cache is disabled (REPOLYZER_CACHE=off)")
+		}
+
+		if err := cache.Clear(); err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+
+		fmt.Println(output.SuccessStyle.Render("🧹 Cleared response cache at " + cache.Dir()))
+		return nil
+	},
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how many API responses are cached",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache := github.DefaultCache()
+		if cache == nil {
+			return fmt.Errorf("the response cache is disabled (REPOLYZER_CACHE=off)")
+		}
+
+		stats := cache.Stats()
+
+		fmt.Println(output.SectionStyle.Render("🗄️ Response Cache"))
+		fmt.Printf("Directory   : %s\n", stats.Dir)
+		fmt.Printf("Entries     : %d\n", stats.Entries)
+		fmt.Printf("Size        : %.1f MB / %.1f MB\n", float64(stats.Bytes)/(1<<20), float64(stats.MaxBytes)/(1<<20))
+		fmt.Printf("TTL         : %s\n", stats.TTL)
+		if stats.Entries > 0 {
+			fmt.Printf("Oldest      : %s\n", stats.Oldest.Format("2006-01-02 15:04"))
+			fmt.Printf("Newest      : %s\n", stats.Newest.Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd, cacheStatsCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+	"github.com/agnivo988/Repo-lyzer/internal/output"
+)
+
+// Exit codes of the check command.
+const (
+	exitPolicyFailed = 1 // A rule failed
+	exitCheckError   = 2 // The check couldn't run
+)
+
+var (
+	policyPath string
+	junitPath  string
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check owner/repo [owner/repo...]",
+	Short: "Check repositories against a policy of metric thresholds",
+	Long: "Check repositories against the thresholds of a policy file and exit non-zero\n" +
+		"when any rule fails, for use as a CI quality gate. A YAML or JSON policy lists\n" +
+		"rules on any health metric, health_score, maturity_score, security_high,\n" +
+		"security_findings, test_ratio or activity_trend:\n\n" +
+		"  rules:\n" +
+		"    - metric: health_score\n" +
+		"      threshold: 70\n" +
+		"    - metric: bus_factor\n" +
+		"      op: \">=\"\n" +
+		"      threshold: 2\n\n" +
+		"A rule on a metric without data fails.\n\n" +
+		"Exit codes: 0 when every rule passed, 1 when a rule failed, 2 when the check\n" +
+		"couldn't run.",
+	// Arguments are checked in runCheck so that usage errors exit with 2
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return checkError(runCheck(cmd, args))
+	},
+}
+
+// checkError gives err the exit code of a check that couldn't run, unless
+// it already has one.
+func checkError(err error) error {
+	var exit *exitError
+	if err != nil && !errors.As(err, &exit) {
+		err = &exitError{code: exitCheckError, err: err}
+	}
+	return err
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("check needs at least one owner/repo")
+	}
+	if policyPath == "" {
+		return fmt.Errorf("--policy is required")
+	}
+	if err := checkFormat("text"); err != nil {
+		return err
+	}
+	policy, err := analyzer.LoadPolicy(policyPath)
+	if err != nil {
+		return err
+	}
+	model, err := healthModel()
+	if err != nil {
+		return err
+	}
+
+	pipeline := github.NewPipeline(newClient(), github.DefaultWorkers)
+	results, errs := pipeline.FetchAll(cmd.Context(), args, fetchOptions())
+	var reports []analyzer.PolicyReport
+	for i, data := range results {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		// Rules on metrics that failed fetches left without data fail
+		a := analyzer.Analyze(data, days, model)
+		report := policy.Check(a)
+		output.PrintPolicy(report)
+		output.PrintDataWarnings(a.DataWarnings())
+		reports = append(reports, report)
+	}
+
+	if junitPath != "" {
+		f, err := os.Create(junitPath)
+		if err != nil {
+			return fmt.Errorf("failed to create JUnit report: %w", err)
+		}
+		if err := output.WriteJUnit(f, reports); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write JUnit report: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write JUnit report: %w", err)
+		}
+	}
+
+	failed := 0
+	for _, r := range reports {
+		if !r.Passed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return &exitError{code: exitPolicyFailed, err: fmt.Errorf("policy check failed for %d of %d repositories", failed, len(reports))}
+	}
+	return nil
+}
+
+func init() {
+	checkCmd.Flags().StringVar(&policyPath, "policy", "", "YAML or JSON policy file of metric thresholds (required)")
+	checkCmd.Flags().StringVar(&junitPath, "junit", "", "also write the results as a JUnit XML report to this file")
+	// Invalid flags, including the shared ones, are usage errors too
+	checkCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return checkError(err)
+	})
+	checkCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return checkError(rootCmd.PersistentPreRunE(cmd, args))
+	}
+	rootCmd.AddCommand(checkCmd)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Connection flags shared by every command that talks to GitHub. Empty
+// values fall back to the environment (GITHUB_API_URL, GITHUB_GRAPHQL_URL,
+// REPOLYZER_CA_BUNDLE) and then to github.com; an unset --max-items or
+// --timeout falls back to REPOLYZER_MAX_ITEMS or REPOLYZER_TIMEOUT and then
+// to github.DefaultMaxItems or github.DefaultTimeout.
+var (
+	apiURL     string
+	graphqlURL string
+	caBundle   string
+	maxItems   int
+	timeout    time.Duration
+)
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&apiURL, "api-url", "", "GitHub REST API URL, e.g. https://github.example.com/api/v3 for GitHub Enterprise Server")
+	flags.StringVar(&graphqlURL, "graphql-url", "", "GitHub GraphQL API URL (derived from --api-url by default)")
+	flags.StringVar(&caBundle, "ca-bundle", "", "PEM file with additional trusted CA certificates")
+	flags.IntVar(&maxItems, "max-items", github.DefaultMaxItems, "cap on the commits, issues, comments and pull requests fetched per list; 0 removes it")
+	flags.DurationVar(&timeout, "timeout", github.DefaultTimeout, "time limit for a single GitHub API request, e.g. 2m; 0 removes it")
+}
+
+// newClient creates a GitHub client configured by the connection flags.
+func newClient() *github.Client {
+	return github.NewClient(clientOptions()...)
+}
+
+// clientOptions returns the client options selected by the connection
+// flags, for the clients the dashboard creates.
+func clientOptions() []github.Option {
+	var opts []github.Option
+	if apiURL != "" {
+		opts = append(opts, github.WithBaseURL(apiURL))
+	}
+	if graphqlURL != "" {
+		opts = append(opts, github.WithGraphQLURL(graphqlURL))
+	}
+	if caBundle != "" {
+		opts = append(opts, github.WithCABundle(caBundle))
+	}
+	if rootCmd.PersistentFlags().Changed("max-items") {
+		opts = append(opts, github.WithMaxItems(maxItems))
+	}
+	if rootCmd.PersistentFlags().Changed("timeout") {
+		opts = append(opts, github.WithTimeout(timeout))
+	}
+	return opts
+}
@@ -5,140 +5,161 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
 	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/agnivo988/Repo-lyzer/internal/github"
+	"github.com/agnivo988/Repo-lyzer/internal/output"
+	"github.com/agnivo988/Repo-lyzer/internal/report"
 )
 
-// RunCompare executes the compare command for two GitHub repositories.
-// It takes two repository identifiers in owner/repo format, analyzes both repositories,
-// and displays a comparison table with metrics like stars, forks, commits, contributors,
-// bus factor, and maturity scores.
-// Parameters:
-//   - r1: First repository in owner/repo format
-//   - r2: Second repository in owner/repo format
-// Returns an error if the comparison fails.
-func RunCompare(r1, r2 string) error {
-	compareCmd.SetArgs([]string{r1, r2})
-	return compareCmd.Execute()
-}
-
+var compareProfile string
 
 var compareCmd = &cobra.Command{
-	Use:   "compare owner1/repo1 owner2/repo2",
-	Short: "Compare two GitHub repositories",
-	Args:  cobra.ExactArgs(2),
+	Use:   "compare owner1/repo1 owner2/repo2 [owner/repo...]",
+	Short: "Compare two or more GitHub repositories",
+	Long: "Compare two or more GitHub repositories. Every metric ranks the repositories\n" +
+		"(#1 is best, ★ marks the best value) and an overall ranking weighs them by a\n" +
+		"profile for the intended use: balanced (default), enterprise (enterprise adoption),\n" +
+		"hobby (hobby use) or contributor (a contribution target). --profile also takes a\n" +
+		"YAML or JSON file with a name, a description and weights by metric ID, e.g.\n\n" +
+		"  name: security-first\n" +
+		"  description: regulated environments\n" +
+		"  weights: {security_findings: 3, has_license: 2, health_score: 1}\n\n" +
+		"Every repository lists the weighted metrics it leads on as the rationale.\n\n" +
+		"Formats: text (default), json or markdown.",
+	Args: cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-
-		// Parse repo names
-		r1 := strings.Split(args[0], "/")
-		r2 := strings.Split(args[1], "/")
-
-		if len(r1) != 2 || len(r2) != 2 {
-			return fmt.Errorf("repositories must be in owner/repo format")
+		if err := checkFormat(append([]string{"text"}, report.CompareFormats...)...); err != nil {
+			return err
 		}
-
-		client := github.NewClient()
-
-		repo1, err := client.GetRepo(r1[0], r1[1])
+		model, err := healthModel()
 		if err != nil {
 			return err
 		}
-
-		_, _ = client.GetLanguages(r1[0], r1[1])
-		commits1, _ := client.GetCommits(r1[0], r1[1], 14)
-		contributors1, _ := client.GetContributors(r1[0], r1[1])
-		_, _ = client.GetFileTree(r1[0], r1[1], repo1.DefaultBranch)
-		bus1, risk1 := analyzer.BusFactor(contributors1)
-
-		maturityScore1, maturityLevel1 :=
-			analyzer.RepoMaturityScore(repo1, len(commits1), len(contributors1), false)
-
-		// ---------- Fetch Repo 2 ----------
-		repo2, err := client.GetRepo(r2[0], r2[1])
+		profile, err := analyzer.SelectWeightProfile(compareProfile)
 		if err != nil {
 			return err
 		}
 
-		_, _ = client.GetLanguages(r2[0], r2[1])
-		commits2, _ := client.GetCommits(r2[0], r2[1], 14)
-		contributors2, _ := client.GetContributors(r2[0], r2[1])
-		_, _ = client.GetFileTree(r2[0], r2[1], repo2.DefaultBranch)
-		bus2, risk2 := analyzer.BusFactor(contributors2)
+		// All repositories are fetched at once on a shared worker pool
+		pipeline := github.NewPipeline(newClient(), github.DefaultWorkers)
+		results, errs := pipeline.FetchAll(cmd.Context(), args, fetchOptions())
+		analyses := make([]analyzer.Analysis, len(results))
+		for i, err := range errs {
+			if err != nil {
+				return err
+			}
+			analyses[i] = analyzer.Analyze(results[i], days, model)
+		}
+		comparison := analyzer.Compare(analyses, profile)
 
-		maturityScore2, maturityLevel2 :=
-			analyzer.RepoMaturityScore(repo2, len(commits2), len(contributors2), false)
+		if format != "text" {
+			return report.WriteComparison(cmd.OutOrStdout(), report.BuildComparison(analyses, comparison), format)
+		}
 
 		// ---------- Output Table ----------
-		fmt.Println("\n📊 Repository Comparison")
+		fmt.Printf("\n📊 Repository Comparison (last %d days)\n", days)
 
+		header := []string{"Metric", "Weight"}
+		for _, a := range analyses {
+			header = append(header, a.Repo.FullName)
+		}
 		table := tablewriter.NewWriter(os.Stdout)
-		table.Header([]string{"Metric", repo1.FullName, repo2.FullName})
-
-		table.Append([]string{"⭐ Stars",
-			fmt.Sprintf("%d", repo1.Stars),
-			fmt.Sprintf("%d", repo2.Stars),
-		})
-
-		table.Append([]string{"🍴 Forks",
-			fmt.Sprintf("%d", repo1.Forks),
-			fmt.Sprintf("%d", repo2.Forks),
-		})
-
-		table.Append([]string{"📦 Commits (1y)",
-			fmt.Sprintf("%d", len(commits1)),
-			fmt.Sprintf("%d", len(commits2)),
-		})
-
-		table.Append([]string{"👥 Contributors",
-			fmt.Sprintf("%d", len(contributors1)),
-			fmt.Sprintf("%d", len(contributors2)),
-		})
-
-		table.Append([]string{"⚠️ Bus Factor",
-			fmt.Sprintf("%d (%s)", bus1, risk1),
-			fmt.Sprintf("%d (%s)", bus2, risk2),
-		})
-
-		table.Append([]string{"🏗️ Maturity",
-			fmt.Sprintf("%s (%d)", maturityLevel1, maturityScore1),
-			fmt.Sprintf("%s (%d)", maturityLevel2, maturityScore2),
-		})
+		table.Header(header)
+
+		for _, r := range comparison.Metrics {
+			row := []string{r.Metric.Name, analyzer.FormatWeight(r.Weight)}
+			for i := range analyses {
+				row = append(row, rankedCell(r, i))
+			}
+			table.Append(row)
+		}
+
+		// Info rows from a failed fetch are flagged rather than shown empty
+		info := []struct {
+			name  string
+			tasks []string
+			value func(a analyzer.Analysis) string
+		}{
+			{"⚠️ Bus Factor Risk", []string{github.TaskContributors}, func(a analyzer.Analysis) string { return a.BusFactor.Risk }},
+			{"🚀 Release Cadence", []string{github.TaskReleases}, func(a analyzer.Analysis) string { return a.Releases.Cadence }},
+			{"🏗️ Maturity Level", []string{github.TaskCommits, github.TaskContributors, github.TaskReleases}, func(a analyzer.Analysis) string { return a.MaturityLevel }},
+			{"🗂️ Files", []string{github.TaskTree}, func(a analyzer.Analysis) string {
+				return fmt.Sprintf("%d in %d dirs (%s)", a.Files.Files, a.Files.Dirs, analyzer.FormatBytes(a.Files.TotalBytes))
+			}},
+			{"🔤 Top Extension", []string{github.TaskTree}, func(a analyzer.Analysis) string { return a.Files.TopExtension() }},
+			{"📐 Max Depth", []string{github.TaskTree}, func(a analyzer.Analysis) string { return fmt.Sprintf("%d", a.Files.MaxDepth) }},
+			{"📦 Vendored / Generated", []string{github.TaskTree}, func(a analyzer.Analysis) string {
+				return fmt.Sprintf("%d / %d dirs", len(a.Files.Vendored), len(a.Files.Generated))
+			}},
+		}
+		for _, row := range info {
+			cells := []string{row.name, ""}
+			for _, a := range analyses {
+				cell := row.value(a)
+				for _, task := range row.tasks {
+					if a.FetchErrors[task] != nil {
+						cell = "⚠ n/a"
+					}
+				}
+				cells = append(cells, cell)
+			}
+			table.Append(cells)
+		}
 
 		table.Render()
 
+		// ---------- Warnings ----------
+		if warnings := comparison.Warnings(); len(warnings) > 0 {
+			fmt.Println(output.WarningStyle.Render("\n⚠️ Missing data (failed fetches)"))
+			for _, w := range warnings {
+				fmt.Printf("%s, %s: %s\n", w.Repo, w.Metric.Name, w.Message)
+			}
+		}
+		for _, a := range analyses {
+			for _, w := range a.TruncationWarnings() {
+				fmt.Println(output.WarningStyle.Render(fmt.Sprintf("⚠️ %s, %s", a.Repo.FullName, w)))
+			}
+		}
+
+		// ---------- Overall Ranking ----------
+		fmt.Printf("\n🏆 Overall Ranking for %s (%s profile)\n", profile.Description, profile.Name)
+		for _, o := range comparison.Overall {
+			fmt.Printf("%d. %s (%.1f/100)\n", o.Rank, o.Repo, o.Score)
+			for _, w := range o.Wins {
+				fmt.Printf("   ✔ %s\n", w)
+			}
+		}
+
 		// ---------- Verdict ----------
 		fmt.Println("\n Verdict")
-		if maturityScore1 > maturityScore2 {
-			fmt.Printf("➡️ %s appears more mature and stable.\n", repo1.FullName)
-		} else if maturityScore2 > maturityScore1 {
-			fmt.Printf("➡️ %s appears more mature and stable.\n", repo2.FullName)
-		} else {
-			fmt.Println("➡️ Both repositories are similarly mature.")
-		}
+		fmt.Printf("➡️ %s.\n", comparison.Verdict())
 
 		return nil
 	},
 }
 
-func init() {
-	rootCmd.AddCommand(compareCmd)
-}
-
-// countTreeStats counts files, directories, and total size from tree entries
-func countTreeStats(tree []github.TreeEntry) (files, dirs, totalSize int) {
-	for _, entry := range tree {
-		if entry.Type == "blob" {
-			files++
-			totalSize += entry.Size
-		} else if entry.Type == "tree" {
-			dirs++
-		}
+// rankedCell formats repository i's value of a ranked metric with its
+// rank, starring the best value and flagging values lost to failed
+// fetches.
+func rankedCell(r analyzer.MetricRanking, i int) string {
+	if r.Warnings[i] != "" {
+		return "⚠ n/a"
+	}
+	if !r.Known[i] {
+		return "n/a"
 	}
-	return
+	cell := fmt.Sprintf("%s (#%d)", r.Metric.Format(r.Values[i]), r.Ranks[i])
+	if r.IsBest(i) {
+		cell = "★ " + cell
+	}
+	return cell
 }
 
+func init() {
+	compareCmd.Flags().StringVar(&compareProfile, "profile", "", "weight profile: balanced, enterprise, hobby, contributor or a profile file (default balanced)")
+	rootCmd.AddCommand(compareCmd)
+}
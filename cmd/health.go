@@ -0,0 +1,20 @@
+package cmd
+
+import "github.com/agnivo988/Repo-lyzer/internal/analyzer"
+
+// healthConfig is the health model file given with --health-config. When
+// empty, REPOLYZER_HEALTH_CONFIG and then the user config directory are
+// consulted, see analyzer.HealthModelFromEnv.
+var healthConfig string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&healthConfig, "health-config", "", "YAML or JSON file with health scoring rules")
+}
+
+// healthModel returns the health scoring model selected by --health-config.
+func healthModel() (analyzer.HealthModel, error) {
+	if healthConfig != "" {
+		return analyzer.LoadHealthModel(healthConfig)
+	}
+	return analyzer.HealthModelFromEnv()
+}
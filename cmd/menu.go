@@ -4,12 +4,39 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/spf13/cobra"
+
 	"github.com/agnivo988/Repo-lyzer/internal/ui"
 )
 
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Launch the interactive dashboard",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTUI()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+	// Without a subcommand the dashboard opens as well
+	rootCmd.RunE = tuiCmd.RunE
+}
+
 func RunMenu() {
-	if err := ui.Run(); err != nil {
+	if err := runTUI(); err != nil {
 		fmt.Println("Error running application:", err)
 		os.Exit(1)
 	}
 }
+
+// runTUI starts the dashboard with the fetch options, health model and
+// GitHub connection selected by the flags.
+func runTUI() error {
+	model, err := healthModel()
+	if err != nil {
+		return err
+	}
+	return ui.Run(fetchOptions(), model, clientOptions()...)
+}
@@ -1,22 +1,131 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Output flags shared by every command.
+var (
+	days          int
+	fileOwnership int
+	format        string
+	outputPath    string
+	noColor       bool
 )
 
+// outputFile is the file opened for --output, closed once the command ran.
+var outputFile *os.File
+
 var rootCmd = &cobra.Command{
 	Use:   "Repo-lyzer",
 	Short: "Analyze GitHub repositories from the terminal",
-	Long:  "Repo-lyzer is a fast CLI tool written in Go to analyze GitHub repositories.",
+	Long: "Repo-lyzer is a fast CLI tool written in Go to analyze GitHub repositories.\n\n" +
+		"Run it without arguments (or with `tui`) for the interactive dashboard, or use\n" +
+		"the subcommands to script it, e.g. in CI.",
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Arguments were valid, so errors from here on are not usage errors
+		cmd.SilenceUsage = true
+
+		if days <= 0 {
+			return fmt.Errorf("--days must be positive, got %d", days)
+		}
+		if fileOwnership < 0 {
+			return fmt.Errorf("--file-ownership must not be negative, got %d", fileOwnership)
+		}
+
+		// The dashboard draws on the terminal, so it can't write to a file
+		if outputPath != "" && (!cmd.HasParent() || cmd.Name() == "tui") {
+			return errors.New("--output can't be used with the interactive dashboard")
+		}
+		if outputPath != "" {
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			// The text reports print to os.Stdout directly
+			outputFile, os.Stdout = f, f
+			cmd.Root().SetOut(f)
+		}
+		if noColor || outputPath != "" {
+			lipgloss.SetColorProfile(termenv.Ascii)
+		}
+		return nil
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		if outputFile == nil {
+			return nil
+		}
+		return outputFile.Close()
+	},
+}
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.IntVar(&days, "days", analyzer.DefaultDays, "analysis window in days for commits, pull requests and issues")
+	flags.IntVar(&fileOwnership, "file-ownership", 0, "compute the bus factor from file ownership of the newest N commits (one request per commit)")
+	flags.StringVarP(&format, "format", "f", "text", "output format")
+	flags.StringVarP(&outputPath, "output", "o", "", "write the output to a file instead of stdout")
+	flags.BoolVar(&noColor, "no-color", false, "disable colored output (also honors NO_COLOR)")
 }
 
-// Execute is used for cobra commands
+// fetchOptions returns the fetch options selected by the analysis flags,
+// with the files the security checks read.
+func fetchOptions() github.FetchOptions {
+	return github.FetchOptions{
+		CommitDays:  days,
+		CommitFiles: fileOwnership,
+		Workflows:   analyzer.WorkflowPaths,
+		GoModules:   analyzer.UnlockedGoModules,
+	}
+}
+
+// checkFormat returns an error unless --format is one of allowed.
+func checkFormat(allowed ...string) error {
+	for _, f := range allowed {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown format %q: use one of %v", format, allowed)
+}
+
+// exitError makes Execute exit with code instead of 1.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// Execute is used for cobra commands. Interrupting the process cancels
+// the command's context, which aborts any in-flight GitHub requests.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if hint := github.Hint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, "💡", hint)
+		}
+		var exit *exitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.code)
+		}
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,125 @@
+package analyzer
+
+import "github.com/agnivo988/Repo-lyzer/internal/github"
+
+// DefaultDays is the default analysis window in days.
+const DefaultDays = 365
+
+// Analysis is the result of every analyzer for one repository, together
+// with the raw data they were computed from.
+type Analysis struct {
+	Repo          *github.Repo
+	Snapshot      *github.RepoSnapshot // Open work, releases and license of Repo
+	Commits       []github.Commit
+	Contributors  []github.Contributor
+	FileTree      []github.TreeEntry
+	Languages     map[string]int
+	Days          int // Analysis window the history was fetched for
+	HealthScore   int
+	Health        HealthReport // Rule breakdown of HealthScore
+	BusFactor     BusFactorResult
+	FileOwnership *FileOwnership // Nil unless commit files were fetched, see github.FetchOptions.CommitFiles
+	MaturityScore int
+	MaturityLevel string
+	Releases      ReleaseStats
+	PullRequests  PRStats
+	Issues        IssueStats
+	Trend         ActivityTrend
+	Files         FileStats
+	Security      SecurityReport
+	FetchErrors   map[string]error // Failed fetches by task, see github.RepoData.Errors
+	Truncated     map[string]int   // Lists cut short by the max-items cap, see github.RepoData.Truncated
+}
+
+// Analyze runs every analyzer on data fetched for a window of days,
+// scoring health with model.
+func Analyze(data *github.RepoData, days int, model HealthModel) Analysis {
+	issues := AnalyzeIssues(data.Issues, data.Comments, days, data.Truncated[github.TaskComments] > 0)
+	if data.Repo != nil && !data.Repo.HasIssues {
+		issues.Health = "Issues disabled"
+	}
+	bus := TruckFactor(data.Contributors, data.CommitFiles, DefaultBusFactorShare)
+	releases := AnalyzeReleases(data.Releases, data.Tags)
+	pullRequests := AnalyzePullRequests(data.PullRequests, data.Reviews)
+	health := CalculateHealth(HealthInput{
+		Repo:         data.Repo,
+		Commits:      data.Commits,
+		Contributors: data.Contributors,
+		Issues:       issues,
+		PullRequests: pullRequests,
+		Releases:     releases,
+		BusFactor:    bus,
+		FetchErrors:  data.Errors,
+	}, model)
+	maturityScore, maturityLevel := RepoMaturityScore(data.Repo, len(data.Commits), len(data.Contributors), releases.HasReleases())
+
+	var ownership *FileOwnership
+	if len(data.CommitFiles) > 0 {
+		o := AnalyzeFileOwnership(data.CommitFiles)
+		ownership = &o
+	}
+
+	return Analysis{
+		Repo:          data.Repo,
+		Snapshot:      data.Snapshot,
+		Commits:       data.Commits,
+		Contributors:  data.Contributors,
+		FileTree:      data.Tree,
+		Languages:     data.Languages,
+		Days:          days,
+		HealthScore:   health.Score,
+		Health:        health,
+		BusFactor:     bus,
+		FileOwnership: ownership,
+		MaturityScore: maturityScore,
+		MaturityLevel: maturityLevel,
+		Releases:      releases,
+		PullRequests:  pullRequests,
+		Issues:        issues,
+		Trend:         AnalyzeActivityTrend(data.Commits, days, data.Truncated[github.TaskCommits] > 0),
+		Files:         AnalyzeFiles(data.Tree),
+		Security:      security(data),
+		FetchErrors:   data.Errors,
+		Truncated:     data.Truncated,
+	}
+}
+
+// security runs the security checks on data, skipping those whose input
+// failed to fetch or was only partly fetched. Without a file tree every
+// check is skipped, since an empty tree would fail the checks for missing
+// files.
+func security(data *github.RepoData) SecurityReport {
+	skip := make(map[string]string)
+	if _, failed := data.Errors[github.TaskTree]; failed {
+		for _, check := range SecurityChecks {
+			skip[check.ID] = "file tree unavailable"
+		}
+		return AnalyzeSecurity(nil, false, nil, nil, skip)
+	}
+
+	_, workflowsFailed := data.Errors[github.TaskWorkflows]
+	workflows := ""
+	switch {
+	case workflowsFailed:
+		workflows = "workflow files unavailable"
+	case data.Workflows == nil:
+		workflows = "workflow files not fetched"
+	case data.WorkflowsTruncated:
+		workflows = "not every workflow file was read"
+	}
+	if workflows != "" {
+		skip["unpinned-action"] = workflows
+		skip["workflow-permissions"] = workflows
+	}
+
+	_, goModulesFailed := data.Errors[github.TaskGoModules]
+	switch {
+	case goModulesFailed:
+		skip["missing-lockfile"] = "go.mod files unavailable"
+	case data.GoModules == nil:
+		skip["missing-lockfile"] = "go.mod files not fetched"
+	case data.GoModulesTruncated:
+		skip["missing-lockfile"] = "not every go.mod file was read"
+	}
+	return AnalyzeSecurity(data.Tree, data.TreeTruncated, data.Workflows, data.GoModules, skip)
+}
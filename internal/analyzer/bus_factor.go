@@ -2,35 +2,181 @@
 // It includes calculations for repository health, maturity, bus factor, and other metrics.
 package analyzer
 
-import "github.com/agnivo988/Repo-lyzer/internal/github"
+import (
+	"sort"
 
-// BusFactor calculates the bus factor of a repository based on contributor commit distribution.
-// The bus factor indicates how risky it is if key contributors leave the project.
-// It returns a score from 1-3 and a risk level string.
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// DefaultBusFactorShare is the share of the work the contributors counted
+// by the bus factor must account for together.
+const DefaultBusFactorShare = 0.5
+
+// Bus factor methods, see BusFactorResult.Method.
+const (
+	BusFactorByCommits       = "commits"
+	BusFactorByFileOwnership = "file ownership"
+)
+
+// BusFactorResult is the bus (or truck) factor of a repository: how few
+// contributors would have to leave before most of the project's knowledge
+// leaves with them.
+type BusFactorResult struct {
+	Count   int      // Minimum number of contributors covering Share of the work
+	Members []string // Those contributors, most active first
+	Share   float64
+	Method  string // BusFactorByCommits or BusFactorByFileOwnership
+	Risk    string
+}
+
+// BusFactor calculates the bus factor of a repository as the minimum
+// number of contributors who together made at least share of all commits.
+// A share of zero or less uses DefaultBusFactorShare.
 // Parameters:
 //   - contributors: Slice of repository contributors with their commit counts
-// Returns:
-//   - int: Risk score (1=High Risk, 2=Medium Risk, 3=Low Risk)
-//   - string: Risk level description
-func BusFactor(contributors []github.Contributor) (int, string) {
-	if len(contributors) == 0 {
-		return 0, "Unknown"
+//   - share: Share of commits the counted contributors must cover, e.g. 0.5
+//
+// Returns the count, the contributors counted and the risk level; the
+// count is 0 and the risk "Unknown" without contributors.
+func BusFactor(contributors []github.Contributor, share float64) BusFactorResult {
+	if share <= 0 {
+		share = DefaultBusFactorShare
 	}
+	result := BusFactorResult{Share: share, Method: BusFactorByCommits}
+
+	sorted := append([]github.Contributor(nil), contributors...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Commits > sorted[j].Commits })
 
 	total := 0
-	for _, c := range contributors {
+	for _, c := range sorted {
 		total += c.Commits
 	}
+	if total == 0 {
+		result.Risk = busRisk(0)
+		return result
+	}
+
+	covered := 0
+	for _, c := range sorted {
+		if float64(covered) >= share*float64(total) {
+			break
+		}
+		covered += c.Commits
+		result.Members = append(result.Members, c.Login)
+	}
+
+	result.Count = len(result.Members)
+	result.Risk = busRisk(result.Count)
+	return result
+}
+
+// FileOwner is a contributor and the number of files they own.
+type FileOwner struct {
+	Login string
+	Files int
+}
+
+// FileOwnership describes who owns the files a sample of commits changed,
+// the owner of a file being whoever changed the most lines of it.
+type FileOwnership struct {
+	Commits int         // Commits sampled
+	Files   int         // Files they changed
+	Owners  []FileOwner // Most files first
+}
 
-	top := contributors[0].Commits
-	ratio := float64(top) / float64(total)
+// AnalyzeFileOwnership finds the owner of every file commits changed.
+// Commits without an author login are ignored.
+func AnalyzeFileOwnership(commits []github.CommitDetail) FileOwnership {
+	ownership := FileOwnership{Commits: len(commits)}
 
+	// Changed lines per file and author
+	changes := make(map[string]map[string]int)
+	for _, c := range commits {
+		author := c.AuthorLogin()
+		if author == "" {
+			continue
+		}
+		for _, f := range c.Files {
+			if changes[f.Filename] == nil {
+				changes[f.Filename] = make(map[string]int)
+			}
+			changes[f.Filename][author] += f.Changes
+		}
+	}
+
+	owned := make(map[string]int)
+	for _, authors := range changes {
+		owner, most := "", -1
+		for author, n := range authors {
+			if n > most || (n == most && author < owner) {
+				owner, most = author, n
+			}
+		}
+		owned[owner]++
+	}
+
+	for owner, files := range owned {
+		ownership.Owners = append(ownership.Owners, FileOwner{Login: owner, Files: files})
+	}
+	sort.Slice(ownership.Owners, func(i, j int) bool {
+		a, b := ownership.Owners[i], ownership.Owners[j]
+		if a.Files != b.Files {
+			return a.Files > b.Files
+		}
+		return a.Login < b.Login
+	})
+	ownership.Files = len(changes)
+	return ownership
+}
+
+// FileOwnershipBusFactor calculates the truck factor from which files each
+// contributor owns, see AnalyzeFileOwnership. Owners are removed greedily,
+// biggest first, until at least share of the files are left without their
+// owner; the removed owners are the truck factor. A share of zero or less
+// uses DefaultBusFactorShare. It returns a zero Count when commits carry
+// no file data.
+func FileOwnershipBusFactor(commits []github.CommitDetail, share float64) BusFactorResult {
+	if share <= 0 {
+		share = DefaultBusFactorShare
+	}
+	result := BusFactorResult{Share: share, Method: BusFactorByFileOwnership}
+
+	ownership := AnalyzeFileOwnership(commits)
+	orphaned := 0
+	for _, owner := range ownership.Owners {
+		if float64(orphaned) >= share*float64(ownership.Files) {
+			break
+		}
+		orphaned += owner.Files
+		result.Members = append(result.Members, owner.Login)
+	}
+
+	result.Count = len(result.Members)
+	result.Risk = busRisk(result.Count)
+	return result
+}
+
+// busRisk maps a bus factor to a risk level.
+func busRisk(count int) string {
 	switch {
-	case ratio > 0.7:
-		return 1, "High Risk"
-	case ratio > 0.4:
-		return 2, "Medium Risk"
+	case count == 0:
+		return "Unknown"
+	case count == 1:
+		return "High Risk"
+	case count <= 3:
+		return "Medium Risk"
 	default:
-		return 3, "Low Risk"
+		return "Low Risk"
+	}
+}
+
+// TruckFactor returns the file ownership bus factor when commit file data
+// is available and the commit share bus factor otherwise.
+func TruckFactor(contributors []github.Contributor, commitFiles []github.CommitDetail, share float64) BusFactorResult {
+	if len(commitFiles) > 0 {
+		if result := FileOwnershipBusFactor(commitFiles, share); result.Count > 0 {
+			return result
+		}
 	}
+	return BusFactor(contributors, share)
 }
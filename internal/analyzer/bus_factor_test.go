@@ -0,0 +1,261 @@
+package analyzer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestBusRisk(t *testing.T) {
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{0, "Unknown"},
+		{1, "High Risk"},
+		{2, "Medium Risk"},
+		{3, "Medium Risk"},
+		{4, "Low Risk"},
+		{20, "Low Risk"},
+	}
+
+	for _, tt := range tests {
+		if got := busRisk(tt.count); got != tt.want {
+			t.Errorf("busRisk(%d) = %q, want %q", tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestBusFactor(t *testing.T) {
+	tests := []struct {
+		name         string
+		contributors []github.Contributor
+		share        float64
+		wantMembers  []string
+		wantShare    float64
+		wantRisk     string
+	}{
+		{
+			name:      "no contributors",
+			share:     0.5,
+			wantShare: 0.5,
+			wantRisk:  "Unknown",
+		},
+		{
+			name:         "no commits",
+			contributors: contributors(0, 0),
+			share:        0.5,
+			wantShare:    0.5,
+			wantRisk:     "Unknown",
+		},
+		{
+			name:         "single maintainer",
+			contributors: contributors(90, 5, 5),
+			share:        0.5,
+			wantMembers:  []string{"c0"},
+			wantShare:    0.5,
+			wantRisk:     "High Risk",
+		},
+		{
+			name:         "exactly half",
+			contributors: contributors(50, 50),
+			share:        0.5,
+			wantMembers:  []string{"c0"},
+			wantShare:    0.5,
+			wantRisk:     "High Risk",
+		},
+		{
+			name:         "sorted by commits",
+			contributors: contributors(10, 30, 25, 35),
+			share:        0.5,
+			wantMembers:  []string{"c3", "c1"},
+			wantShare:    0.5,
+			wantRisk:     "Medium Risk",
+		},
+		{
+			name:         "default share",
+			contributors: contributors(25, 25, 25, 25),
+			share:        0,
+			wantMembers:  []string{"c0", "c1"},
+			wantShare:    DefaultBusFactorShare,
+			wantRisk:     "Medium Risk",
+		},
+		{
+			name:         "higher share",
+			contributors: contributors(10, 10, 10, 10, 10, 10, 10, 10, 10, 10),
+			share:        0.8,
+			wantMembers:  []string{"c0", "c1", "c2", "c3", "c4", "c5", "c6", "c7"},
+			wantShare:    0.8,
+			wantRisk:     "Low Risk",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BusFactor(tt.contributors, tt.share)
+			checkBusFactor(t, got, BusFactorByCommits, tt.wantMembers, tt.wantShare, tt.wantRisk)
+		})
+	}
+}
+
+func TestAnalyzeFileOwnership(t *testing.T) {
+	commits := []github.CommitDetail{
+		commitDetail("alice", map[string]int{"a.go": 10, "b.go": 5}),
+		commitDetail("bob", map[string]int{"a.go": 4, "c.go": 3}),
+		commitDetail("bob", map[string]int{"a.go": 4}),
+		commitDetail("carol", map[string]int{"b.go": 5, "d.go": 1}),
+		commitDetail("", map[string]int{"e.go": 100}),
+	}
+
+	got := AnalyzeFileOwnership(commits)
+	if got.Commits != 5 || got.Files != 4 {
+		t.Errorf("got %d commits and %d files, want 5 and 4", got.Commits, got.Files)
+	}
+	// a.go: alice 10, bob 8; b.go: alice and carol tie at 5, alice wins by name
+	want := "[{alice 2} {bob 1} {carol 1}]"
+	if fmt.Sprint(got.Owners) != want {
+		t.Errorf("owners = %v, want %s", got.Owners, want)
+	}
+}
+
+func TestFileOwnershipBusFactor(t *testing.T) {
+	tests := []struct {
+		name        string
+		commits     []github.CommitDetail
+		share       float64
+		wantMembers []string
+		wantRisk    string
+	}{
+		{
+			name:     "no file data",
+			commits:  []github.CommitDetail{commitDetail("alice", nil)},
+			share:    0.5,
+			wantRisk: "Unknown",
+		},
+		{
+			name: "one owner of most files",
+			commits: []github.CommitDetail{
+				commitDetail("alice", map[string]int{"a.go": 1, "b.go": 1, "c.go": 1}),
+				commitDetail("bob", map[string]int{"d.go": 1}),
+			},
+			share:       0.5,
+			wantMembers: []string{"alice"},
+			wantRisk:    "High Risk",
+		},
+		{
+			name: "spread ownership",
+			commits: []github.CommitDetail{
+				commitDetail("alice", map[string]int{"a.go": 1, "b.go": 1}),
+				commitDetail("bob", map[string]int{"c.go": 1, "d.go": 1}),
+				commitDetail("carol", map[string]int{"e.go": 1, "f.go": 1}),
+				commitDetail("dave", map[string]int{"g.go": 1, "h.go": 1}),
+			},
+			share:       0.75,
+			wantMembers: []string{"alice", "bob", "carol"},
+			wantRisk:    "Medium Risk",
+		},
+		{
+			name: "many small owners",
+			commits: []github.CommitDetail{
+				commitDetail("a", map[string]int{"1": 1}),
+				commitDetail("b", map[string]int{"2": 1}),
+				commitDetail("c", map[string]int{"3": 1}),
+				commitDetail("d", map[string]int{"4": 1}),
+				commitDetail("e", map[string]int{"5": 1}),
+				commitDetail("f", map[string]int{"6": 1}),
+				commitDetail("g", map[string]int{"7": 1}),
+				commitDetail("h", map[string]int{"8": 1}),
+			},
+			share:       0,
+			wantMembers: []string{"a", "b", "c", "d"},
+			wantRisk:    "Low Risk",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			share := tt.share
+			if share <= 0 {
+				share = DefaultBusFactorShare
+			}
+			got := FileOwnershipBusFactor(tt.commits, tt.share)
+			checkBusFactor(t, got, BusFactorByFileOwnership, tt.wantMembers, share, tt.wantRisk)
+		})
+	}
+}
+
+func TestTruckFactor(t *testing.T) {
+	team := contributors(60, 20, 20)
+
+	tests := []struct {
+		name        string
+		commitFiles []github.CommitDetail
+		wantMethod  string
+		wantMembers []string
+	}{
+		{"without file data", nil, BusFactorByCommits, []string{"c0"}},
+		{"commits without files", []github.CommitDetail{commitDetail("alice", nil)}, BusFactorByCommits, []string{"c0"}},
+		{
+			"file ownership",
+			[]github.CommitDetail{
+				commitDetail("alice", map[string]int{"a.go": 1}),
+				commitDetail("bob", map[string]int{"b.go": 1}),
+				commitDetail("carol", map[string]int{"c.go": 1}),
+			},
+			BusFactorByFileOwnership,
+			[]string{"alice", "bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruckFactor(team, tt.commitFiles, 0.5)
+			if got.Method != tt.wantMethod {
+				t.Errorf("method = %q, want %q", got.Method, tt.wantMethod)
+			}
+			if fmt.Sprint(got.Members) != fmt.Sprint(tt.wantMembers) {
+				t.Errorf("members = %v, want %v", got.Members, tt.wantMembers)
+			}
+		})
+	}
+}
+
+func checkBusFactor(t *testing.T, got BusFactorResult, method string, members []string, share float64, risk string) {
+	t.Helper()
+	if got.Count != len(members) || fmt.Sprint(got.Members) != fmt.Sprint(members) {
+		t.Errorf("count %d with members %v, want %d with %v", got.Count, got.Members, len(members), members)
+	}
+	if got.Method != method {
+		t.Errorf("method = %q, want %q", got.Method, method)
+	}
+	if got.Share != share {
+		t.Errorf("share = %v, want %v", got.Share, share)
+	}
+	if got.Risk != risk {
+		t.Errorf("risk = %q, want %q", got.Risk, risk)
+	}
+}
+
+// contributors returns contributors c0, c1, ... with the given commit
+// counts.
+func contributors(commits ...int) []github.Contributor {
+	var result []github.Contributor
+	for i, n := range commits {
+		result = append(result, github.Contributor{Login: fmt.Sprintf("c%d", i), Commits: n})
+	}
+	return result
+}
+
+// commitDetail returns a commit by login changing the given number of
+// lines per file.
+func commitDetail(login string, changes map[string]int) github.CommitDetail {
+	var c github.CommitDetail
+	if login != "" {
+		c.Author = &github.User{Login: login}
+	}
+	for name, n := range changes {
+		c.Files = append(c.Files, github.CommitFile{Filename: name, Changes: n})
+	}
+	return c
+}
@@ -0,0 +1,442 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CompareMetric is a metric repositories are ranked by in a comparison.
+type CompareMetric struct {
+	ID           string // Key of Analysis.PolicyMetrics
+	Name         string
+	HigherBetter bool
+}
+
+// Format prints a value of the metric, whole numbers without decimals.
+func (m CompareMetric) Format(v float64) string {
+	if m.ID == "has_license" {
+		if v > 0 {
+			return "yes"
+		}
+		return "no"
+	}
+	return formatMetric(v)
+}
+
+// FormatWeight prints the weight of a metric in a profile, whole numbers
+// without decimals.
+func FormatWeight(w float64) string {
+	return formatMetric(w)
+}
+
+// CompareMetrics are the metrics of a comparison, in display order.
+var CompareMetrics = []CompareMetric{
+	{ID: "health_score", Name: "Health Score", HigherBetter: true},
+	{ID: "bus_factor", Name: "Bus Factor", HigherBetter: true},
+	{ID: "maturity_score", Name: "Maturity", HigherBetter: true},
+	{ID: "commits", Name: "Commits", HigherBetter: true},
+	{ID: "contributors", Name: "Contributors", HigherBetter: true},
+	{ID: "issue_response_score", Name: "Issue Response", HigherBetter: true},
+	{ID: "pr_merge_ratio", Name: "PR Merge Ratio", HigherBetter: true},
+	{ID: "releases", Name: "Releases", HigherBetter: true},
+	{ID: "days_since_push", Name: "Days Since Push", HigherBetter: false},
+	{ID: "has_license", Name: "License", HigherBetter: true},
+	{ID: "test_ratio", Name: "Test Ratio", HigherBetter: true},
+	{ID: "security_findings", Name: "Security Findings", HigherBetter: false},
+	{ID: "stars", Name: "Stars", HigherBetter: true},
+	{ID: "forks", Name: "Forks", HigherBetter: true},
+}
+
+// WeightProfile weights the metrics of a comparison for one use case.
+// Metrics it leaves out are still ranked but don't count towards the
+// overall score.
+type WeightProfile struct {
+	Name        string             `json:"name" yaml:"name"`
+	Description string             `json:"description" yaml:"description"` // The use case, e.g. "enterprise adoption"
+	Weights     map[string]float64 `json:"weights" yaml:"weights"`         // Keyed by CompareMetric.ID
+}
+
+// WeightProfiles are the built-in weight profiles, the default first.
+var WeightProfiles = []WeightProfile{
+	{
+		Name:        "balanced",
+		Description: "general use",
+		Weights: map[string]float64{
+			"health_score": 3, "bus_factor": 2, "maturity_score": 2, "commits": 1.5, "contributors": 1,
+			"issue_response_score": 1.5, "pr_merge_ratio": 1, "releases": 1, "days_since_push": 1,
+			"has_license": 1, "test_ratio": 1, "security_findings": 1, "stars": 1, "forks": 0.5,
+		},
+	},
+	{
+		Name:        "enterprise",
+		Description: "enterprise adoption",
+		Weights: map[string]float64{
+			"health_score": 3, "bus_factor": 3, "has_license": 3, "maturity_score": 2, "releases": 2,
+			"security_findings": 2, "issue_response_score": 2, "days_since_push": 1, "commits": 1,
+			"contributors": 1, "test_ratio": 1,
+		},
+	},
+	{
+		Name:        "hobby",
+		Description: "hobby use",
+		Weights: map[string]float64{
+			"stars": 3, "days_since_push": 2, "commits": 2, "health_score": 1, "issue_response_score": 1,
+			"has_license": 1, "releases": 1, "forks": 1,
+		},
+	},
+	{
+		Name:        "contributor",
+		Description: "a contribution target",
+		Weights: map[string]float64{
+			"issue_response_score": 3, "pr_merge_ratio": 3, "commits": 2, "contributors": 2,
+			"days_since_push": 2, "health_score": 1, "bus_factor": 1, "test_ratio": 1,
+		},
+	},
+}
+
+// DefaultWeightProfile returns the balanced profile.
+func DefaultWeightProfile() WeightProfile {
+	return WeightProfiles[0]
+}
+
+// WeightProfileNames lists the built-in profiles.
+func WeightProfileNames() []string {
+	names := make([]string, len(WeightProfiles))
+	for i, p := range WeightProfiles {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// SelectWeightProfile returns the built-in profile called name, or loads
+// the profile file at that path. An empty name selects the default.
+func SelectWeightProfile(name string) (WeightProfile, error) {
+	if name == "" {
+		return DefaultWeightProfile(), nil
+	}
+	for _, p := range WeightProfiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	if _, err := os.Stat(name); err != nil {
+		return WeightProfile{}, fmt.Errorf("unknown weight profile %q (want one of %s or a profile file)", name, strings.Join(WeightProfileNames(), ", "))
+	}
+	return LoadWeightProfile(name)
+}
+
+// LoadWeightProfile reads a YAML or JSON (by .json extension) weight
+// profile. A profile without a name is named after its file.
+func LoadWeightProfile(path string) (WeightProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return WeightProfile{}, fmt.Errorf("failed to read weight profile: %w", err)
+	}
+
+	var p WeightProfile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &p)
+	} else {
+		err = yaml.Unmarshal(data, &p)
+	}
+	if err != nil {
+		return WeightProfile{}, fmt.Errorf("failed to parse weight profile %s: %w", path, err)
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if p.Description == "" {
+		p.Description = p.Name
+	}
+
+	if err := p.Validate(); err != nil {
+		return WeightProfile{}, fmt.Errorf("invalid weight profile %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Validate checks that the profile only weights known metrics, none of
+// them negatively, and at least one of them.
+func (p WeightProfile) Validate() error {
+	total := 0.0
+	for id, w := range p.Weights {
+		if !isCompareMetric(id) {
+			ids := make([]string, len(CompareMetrics))
+			for i, m := range CompareMetrics {
+				ids[i] = m.ID
+			}
+			return fmt.Errorf("unknown metric %q (known: %s)", id, strings.Join(ids, ", "))
+		}
+		if w < 0 {
+			return fmt.Errorf("metric %q has negative weight %v", id, w)
+		}
+		total += w
+	}
+	if total == 0 {
+		return fmt.Errorf("no metric has a weight")
+	}
+	return nil
+}
+
+func isCompareMetric(id string) bool {
+	for _, m := range CompareMetrics {
+		if m.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// MetricRanking ranks the repositories of a comparison by one metric. All
+// slices are indexed like Comparison.Repos.
+type MetricRanking struct {
+	Metric   CompareMetric
+	Weight   float64 // Weight of the metric in the comparison's profile
+	Values   []float64
+	Known    []bool   // Whether the repository had data for the metric
+	Ranks    []int    // 1 for the best value, ties share a rank; 0 without data
+	Best     []int    // Indexes of the best repositories; empty when all tie
+	Warnings []string // Why a repository has no data because a fetch failed, "" otherwise
+}
+
+// IsBest reports whether repository i has the best value.
+func (r MetricRanking) IsBest(i int) bool {
+	for _, b := range r.Best {
+		if b == i {
+			return true
+		}
+	}
+	return false
+}
+
+// Win is a weighted metric a repository leads on, with the runner-up's
+// value for contrast.
+type Win struct {
+	Metric   CompareMetric
+	Weight   float64
+	Value    float64
+	Next     float64
+	NextRepo string // "" when no other repository had data
+}
+
+// String explains the win, e.g. "Bus Factor 4 vs 2 (owner/repo), weight 3".
+func (w Win) String() string {
+	if w.NextRepo == "" {
+		return fmt.Sprintf("%s %s, the only one with data, weight %s", w.Metric.Name, w.Metric.Format(w.Value), FormatWeight(w.Weight))
+	}
+	return fmt.Sprintf("%s %s vs %s (%s), weight %s", w.Metric.Name, w.Metric.Format(w.Value), w.Metric.Format(w.Next), w.NextRepo, FormatWeight(w.Weight))
+}
+
+// CompareWarning is a metric a repository of a comparison has no data for
+// because a fetch failed.
+type CompareWarning struct {
+	Repo    string
+	Metric  CompareMetric
+	Message string
+}
+
+// OverallRank is a repository's place in the weighted overall ranking.
+type OverallRank struct {
+	Index int // Index into Comparison.Repos
+	Repo  string
+	Score float64 // 0-100, the weighted share of the best value of each metric
+	Rank  int     // 1 for the best score, ties share a rank
+	Wins  []Win   // The weighted metrics it leads on, heaviest first
+}
+
+// Comparison ranks any number of repositories metric by metric and
+// overall.
+type Comparison struct {
+	Repos   []string
+	Profile WeightProfile
+	Metrics []MetricRanking
+	Overall []OverallRank // Best first
+}
+
+// Warnings lists the metrics without data because of failed fetches, by
+// metric and then repository.
+func (c Comparison) Warnings() []CompareWarning {
+	var warnings []CompareWarning
+	for _, r := range c.Metrics {
+		for i, w := range r.Warnings {
+			if w != "" {
+				warnings = append(warnings, CompareWarning{Repo: c.Repos[i], Metric: r.Metric, Message: w})
+			}
+		}
+	}
+	return warnings
+}
+
+// Verdict names the repository that ranks first for the profile's use
+// case and the heaviest metrics it leads on.
+func (c Comparison) Verdict() string {
+	if len(c.Overall) == 0 {
+		return ""
+	}
+	if len(c.Overall) > 1 && c.Overall[1].Rank == 1 {
+		return fmt.Sprintf("The repositories rank equally for %s", c.Profile.Description)
+	}
+
+	first := c.Overall[0]
+	verdict := fmt.Sprintf("%s ranks first for %s (%.0f/100)", first.Repo, c.Profile.Description, first.Score)
+	var leads []string
+	for _, w := range first.Wins {
+		if len(leads) == 3 {
+			break
+		}
+		leads = append(leads, w.Metric.Name)
+	}
+	switch len(leads) {
+	case 0:
+	case 1:
+		verdict += ", leading on " + leads[0]
+	default:
+		verdict += ", leading on " + strings.Join(leads[:len(leads)-1], ", ") + " and " + leads[len(leads)-1]
+	}
+	return verdict
+}
+
+// Compare ranks analyses by every CompareMetric and weights the metrics
+// with profile. The overall score weights each metric's min-max
+// normalized value, so the best repository of a metric gets its full
+// weight and the worst none; a repository without data for a metric,
+// e.g. because a fetch failed, gets none either.
+func Compare(analyses []Analysis, profile WeightProfile) Comparison {
+	c := Comparison{Profile: profile}
+	metrics := make([]map[string]float64, len(analyses))
+	warnings := make([]map[string]string, len(analyses))
+	for i, a := range analyses {
+		c.Repos = append(c.Repos, a.Repo.FullName)
+		metrics[i] = a.PolicyMetrics()
+		warnings[i] = make(map[string]string)
+		for _, w := range a.MetricWarnings() {
+			warnings[i][w.Metric] = w.Err.Error()
+		}
+	}
+
+	scores := make([]float64, len(analyses))
+	var totalWeight float64
+	for _, metric := range CompareMetrics {
+		r := rankMetric(metric, metrics)
+		r.Weight = profile.Weights[metric.ID]
+		for i := range analyses {
+			r.Warnings = append(r.Warnings, warnings[i][metric.ID])
+		}
+		c.Metrics = append(c.Metrics, r)
+
+		totalWeight += r.Weight
+		for i, share := range normalized(r) {
+			scores[i] += r.Weight * share
+		}
+	}
+
+	for i, repo := range c.Repos {
+		score := 0.0
+		if totalWeight > 0 {
+			// Rounded so that near-ties, e.g. from days_since_push, share a rank
+			score = math.Round(scores[i]/totalWeight*1000) / 10
+		}
+		c.Overall = append(c.Overall, OverallRank{Index: i, Repo: repo, Score: score, Wins: c.wins(i)})
+	}
+	sort.SliceStable(c.Overall, func(i, j int) bool { return c.Overall[i].Score > c.Overall[j].Score })
+	for i := range c.Overall {
+		c.Overall[i].Rank = i + 1
+		if i > 0 && c.Overall[i].Score == c.Overall[i-1].Score {
+			c.Overall[i].Rank = c.Overall[i-1].Rank
+		}
+	}
+	return c
+}
+
+// wins lists the weighted metrics repository i leads on, heaviest first.
+func (c Comparison) wins(i int) []Win {
+	var wins []Win
+	for _, r := range c.Metrics {
+		if r.Weight == 0 || !r.IsBest(i) {
+			continue
+		}
+		w := Win{Metric: r.Metric, Weight: r.Weight, Value: r.Values[i]}
+		next := -1
+		for j := range c.Repos {
+			if r.Known[j] && !r.IsBest(j) && (next < 0 || r.Ranks[j] < r.Ranks[next]) {
+				next = j
+			}
+		}
+		if next >= 0 {
+			w.Next, w.NextRepo = r.Values[next], c.Repos[next]
+		}
+		wins = append(wins, w)
+	}
+	sort.SliceStable(wins, func(a, b int) bool { return wins[a].Weight > wins[b].Weight })
+	return wins
+}
+
+// rankMetric ranks the repositories by one metric.
+func rankMetric(metric CompareMetric, metrics []map[string]float64) MetricRanking {
+	n := len(metrics)
+	r := MetricRanking{Metric: metric, Values: make([]float64, n), Known: make([]bool, n), Ranks: make([]int, n)}
+	for i, m := range metrics {
+		r.Values[i], r.Known[i] = m[metric.ID]
+	}
+
+	better := func(a, b float64) bool {
+		if metric.HigherBetter {
+			return a > b
+		}
+		return a < b
+	}
+	distinct := make(map[float64]bool)
+	for i := range metrics {
+		if !r.Known[i] {
+			continue
+		}
+		distinct[r.Values[i]] = true
+		r.Ranks[i] = 1
+		for j := range metrics {
+			if r.Known[j] && better(r.Values[j], r.Values[i]) {
+				r.Ranks[i]++
+			}
+		}
+	}
+
+	if len(distinct) > 1 {
+		for i, rank := range r.Ranks {
+			if rank == 1 {
+				r.Best = append(r.Best, i)
+			}
+		}
+	}
+	return r
+}
+
+// normalized maps each repository's value to 0-1, 1 being the best value
+// of the comparison. Repositories without data get 0; when every known
+// value is the same, they all get 1.
+func normalized(r MetricRanking) []float64 {
+	out := make([]float64, len(r.Values))
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, v := range r.Values {
+		if r.Known[i] {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	for i, v := range r.Values {
+		switch {
+		case !r.Known[i]:
+		case hi == lo:
+			out[i] = 1
+		case r.Metric.HigherBetter:
+			out[i] = (v - lo) / (hi - lo)
+		default:
+			out[i] = (hi - v) / (hi - lo)
+		}
+	}
+	return out
+}
@@ -0,0 +1,300 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestRankMetric(t *testing.T) {
+	higher := CompareMetric{ID: "stars", HigherBetter: true}
+	lower := CompareMetric{ID: "days_since_push"}
+
+	tests := []struct {
+		name      string
+		metric    CompareMetric
+		values    []float64
+		known     []bool
+		wantRanks []int
+		wantBest  []int
+	}{
+		{"higher is better", higher, []float64{3, 5, 1}, []bool{true, true, true}, []int{2, 1, 3}, []int{1}},
+		{"lower is better", lower, []float64{3, 5, 1}, []bool{true, true, true}, []int{2, 3, 1}, []int{2}},
+		{"shared first place", higher, []float64{5, 5, 1}, []bool{true, true, true}, []int{1, 1, 3}, []int{0, 1}},
+		{"all tie", higher, []float64{2, 2, 2}, []bool{true, true, true}, []int{1, 1, 1}, nil},
+		{"without data", higher, []float64{0, 5, 1}, []bool{false, true, true}, []int{0, 1, 2}, []int{1}},
+		{"one with data", higher, []float64{0, 5}, []bool{false, true}, []int{0, 1}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := make([]map[string]float64, len(tt.values))
+			for i, v := range tt.values {
+				metrics[i] = map[string]float64{}
+				if tt.known[i] {
+					metrics[i][tt.metric.ID] = v
+				}
+			}
+
+			r := rankMetric(tt.metric, metrics)
+			if fmt.Sprint(r.Ranks) != fmt.Sprint(tt.wantRanks) {
+				t.Errorf("ranks = %v, want %v", r.Ranks, tt.wantRanks)
+			}
+			if fmt.Sprint(r.Best) != fmt.Sprint(tt.wantBest) {
+				t.Errorf("best = %v, want %v", r.Best, tt.wantBest)
+			}
+		})
+	}
+}
+
+func TestNormalized(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric CompareMetric
+		values []float64
+		known  []bool
+		want   []float64
+	}{
+		{"higher is better", CompareMetric{HigherBetter: true}, []float64{0, 5, 10}, []bool{true, true, true}, []float64{0, 0.5, 1}},
+		{"lower is better", CompareMetric{}, []float64{0, 5, 10}, []bool{true, true, true}, []float64{1, 0.5, 0}},
+		{"all tie", CompareMetric{HigherBetter: true}, []float64{4, 4}, []bool{true, true}, []float64{1, 1}},
+		{"without data", CompareMetric{HigherBetter: true}, []float64{0, 4, 8}, []bool{false, true, true}, []float64{0, 0, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalized(MetricRanking{Metric: tt.metric, Values: tt.values, Known: tt.known})
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("normalized = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// popular has the stars, solid the health, bus factor and license
+	popular := compareAnalysis("o/popular", 1000, false, 1, 60)
+	solid := compareAnalysis("o/solid", 10, true, 4, 80)
+
+	tests := []struct {
+		name        string
+		profile     string
+		analyses    []Analysis
+		wantOrder   []string
+		wantScores  []float64
+		wantRanks   []int
+		wantVerdict string
+	}{
+		{
+			name:        "hobby favours stars",
+			profile:     "hobby",
+			analyses:    []Analysis{solid, popular},
+			wantOrder:   []string{"o/popular", "o/solid"},
+			wantScores:  []float64{66.7, 58.3},
+			wantRanks:   []int{1, 2},
+			wantVerdict: "o/popular ranks first for hobby use (67/100), leading on Stars",
+		},
+		{
+			name:        "enterprise favours health, bus factor and license",
+			profile:     "enterprise",
+			analyses:    []Analysis{popular, solid},
+			wantOrder:   []string{"o/solid", "o/popular"},
+			wantScores:  []float64{81, 38.1},
+			wantRanks:   []int{1, 2},
+			wantVerdict: "o/solid ranks first for enterprise adoption (81/100), leading on Health Score, Bus Factor and License",
+		},
+		{
+			name:        "identical repositories",
+			profile:     "balanced",
+			analyses:    []Analysis{compareAnalysis("o/a", 5, true, 2, 70), compareAnalysis("o/b", 5, true, 2, 70)},
+			wantOrder:   []string{"o/a", "o/b"},
+			wantScores:  []float64{78.4, 78.4},
+			wantRanks:   []int{1, 1},
+			wantVerdict: "The repositories rank equally for general use",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := SelectWeightProfile(tt.profile)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := Compare(tt.analyses, profile)
+			var order []string
+			var scores []float64
+			var ranks []int
+			for _, o := range c.Overall {
+				order = append(order, o.Repo)
+				scores = append(scores, o.Score)
+				ranks = append(ranks, o.Rank)
+				if c.Repos[o.Index] != o.Repo {
+					t.Errorf("%s has the index of %s", o.Repo, c.Repos[o.Index])
+				}
+			}
+			if fmt.Sprint(order) != fmt.Sprint(tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			if fmt.Sprint(scores) != fmt.Sprint(tt.wantScores) {
+				t.Errorf("scores = %v, want %v", scores, tt.wantScores)
+			}
+			if fmt.Sprint(ranks) != fmt.Sprint(tt.wantRanks) {
+				t.Errorf("ranks = %v, want %v", ranks, tt.wantRanks)
+			}
+			if got := c.Verdict(); got != tt.wantVerdict {
+				t.Errorf("verdict = %q, want %q", got, tt.wantVerdict)
+			}
+		})
+	}
+}
+
+func TestCompareWins(t *testing.T) {
+	c := Compare([]Analysis{
+		compareAnalysis("o/a", 1000, false, 1, 60),
+		compareAnalysis("o/b", 10, true, 4, 80),
+		compareAnalysis("o/c", 500, true, 2, 70),
+	}, WeightProfiles[1])
+
+	wins := make(map[string][]string)
+	for _, o := range c.Overall {
+		for _, w := range o.Wins {
+			wins[o.Repo] = append(wins[o.Repo], w.String())
+		}
+	}
+
+	// A shared lead is a win against the next repository; stars, which only
+	// o/a leads on, carry no weight for enterprise adoption
+	want := map[string][]string{
+		"o/b": {
+			"Health Score 80 vs 70 (o/c), weight 3",
+			"Bus Factor 4 vs 2 (o/c), weight 3",
+			"License yes vs no (o/a), weight 3",
+		},
+		"o/c": {"License yes vs no (o/a), weight 3"},
+	}
+	if fmt.Sprint(wins) != fmt.Sprint(want) {
+		t.Errorf("wins = %q, want %q", wins, want)
+	}
+}
+
+func TestCompareFetchErrors(t *testing.T) {
+	broken := compareAnalysis("o/broken", 10, true, 4, 80)
+	broken.FetchErrors = map[string]error{github.TaskContributors: errors.New("timeout")}
+
+	c := Compare([]Analysis{compareAnalysis("o/ok", 10, true, 2, 80), broken}, DefaultWeightProfile())
+
+	var got []string
+	for _, w := range c.Warnings() {
+		got = append(got, w.Repo+" "+w.Metric.ID+": "+w.Message)
+	}
+	want := []string{"o/broken bus_factor: timeout", "o/broken maturity_score: timeout", "o/broken contributors: timeout"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("warnings = %q, want %q", got, want)
+	}
+
+	// The missing bus factor of o/broken earns it nothing, so o/ok wins
+	// despite its lower bus factor
+	if c.Overall[0].Repo != "o/ok" {
+		t.Errorf("%s ranks first, want o/ok", c.Overall[0].Repo)
+	}
+	for _, r := range c.Metrics {
+		if r.Metric.ID == "bus_factor" && (r.Known[1] || r.Ranks[1] != 0) {
+			t.Errorf("bus factor of o/broken known: %v, rank %d", r.Known[1], r.Ranks[1])
+		}
+	}
+}
+
+func TestSelectWeightProfile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name     string
+		profile  string
+		wantName string
+		wantDesc string
+		wantErr  string
+	}{
+		{name: "default", profile: "", wantName: "balanced", wantDesc: "general use"},
+		{name: "built-in", profile: "contributor", wantName: "contributor", wantDesc: "a contribution target"},
+		{name: "unknown", profile: "startup", wantErr: `unknown weight profile "startup"`},
+		{
+			name:     "yaml file named after itself",
+			profile:  write("security.yaml", "weights:\n  security_findings: 5\n  has_license: 1\n"),
+			wantName: "security", wantDesc: "security",
+		},
+		{
+			name:     "json file",
+			profile:  write("p.json", `{"name": "ops", "description": "operations", "weights": {"days_since_push": 1}}`),
+			wantName: "ops", wantDesc: "operations",
+		},
+		{
+			name:    "unknown metric",
+			profile: write("bad.yaml", "weights:\n  likes: 1\n"),
+			wantErr: `unknown metric "likes"`,
+		},
+		{
+			name:    "negative weight",
+			profile: write("neg.yaml", "weights:\n  stars: -1\n"),
+			wantErr: `metric "stars" has negative weight -1`,
+		},
+		{
+			name:    "no weights",
+			profile: write("zero.yaml", "weights:\n  stars: 0\n"),
+			wantErr: "no metric has a weight",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := SelectWeightProfile(tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectWeightProfile: %v", err)
+			}
+			if p.Name != tt.wantName || p.Description != tt.wantDesc {
+				t.Errorf("got %q (%s), want %q (%s)", p.Name, p.Description, tt.wantName, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestWeightProfilesValid(t *testing.T) {
+	for _, p := range WeightProfiles {
+		if err := p.Validate(); err != nil {
+			t.Errorf("profile %s: %v", p.Name, err)
+		}
+	}
+}
+
+// compareAnalysis returns an analysis that differs from others of its
+// kind only in the given metrics.
+func compareAnalysis(name string, stars int, licensed bool, busFactor, health int) Analysis {
+	repo := &github.Repo{FullName: name, Stars: stars}
+	if licensed {
+		repo.License = &github.License{Key: "mit"}
+	}
+	return Analysis{
+		Repo:        repo,
+		HealthScore: health,
+		BusFactor:   BusFactorResult{Count: busFactor},
+		Trend:       ActivityTrend{Direction: TrendUnknown},
+	}
+}
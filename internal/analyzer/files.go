@@ -1 +1,200 @@
-package analyzer
\ No newline at end of file
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// largestFiles is how many of the biggest files FileStats keeps.
+const largestFiles = 10
+
+// FileGroup is the number and size of the files sharing an extension or a
+// top-level directory.
+type FileGroup struct {
+	Name  string
+	Files int
+	Bytes int
+}
+
+// FileInfo is a single file of the tree.
+type FileInfo struct {
+	Path  string
+	Bytes int
+}
+
+// FileStats describes the composition of a repository's file tree.
+type FileStats struct {
+	Files       int
+	Dirs        int
+	TotalBytes  int
+	ByExtension []FileGroup // Biggest first; "(none)" for files without one
+	ByDirectory []FileGroup // Top-level directories, biggest first; "(root)" for files at the top
+	Largest     []FileInfo
+	SourceFiles int     // Code files that aren't tests, vendored or generated
+	TestFiles   int     // Test files that aren't vendored or generated
+	TestRatio   float64 // TestFiles per SourceFiles, 0 without source files
+	Vendored    []string
+	Generated   []string
+	MaxDepth    int // Directory levels of the deepest file, 1 at the top
+}
+
+// TopExtension returns the extension with the most bytes, or "" for an
+// empty tree.
+func (s FileStats) TopExtension() string {
+	if len(s.ByExtension) == 0 {
+		return ""
+	}
+	return s.ByExtension[0].Name
+}
+
+// Directory names that hold third-party or build output rather than the
+// project's own code. Only names no project uses for its own sources are
+// listed: "build", "out", "gen" or "external" are often packages.
+var (
+	vendoredDirs  = map[string]bool{"vendor": true, "node_modules": true, "third_party": true, "third-party": true, "bower_components": true}
+	generatedDirs = map[string]bool{"dist": true, "__generated__": true, ".next": true}
+	testDirs      = map[string]bool{"test": true, "tests": true, "__tests__": true, "spec": true, "testdata": true}
+)
+
+// sourceExtensions are the extensions counted as code for the test ratio.
+var sourceExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".java": true, ".kt": true, ".scala": true, ".rb": true, ".rs": true, ".php": true,
+	".c": true, ".h": true, ".cc": true, ".cpp": true, ".hpp": true, ".cs": true,
+	".swift": true, ".m": true, ".dart": true, ".ex": true, ".exs": true, ".hs": true,
+	".lua": true, ".vue": true, ".svelte": true, ".sh": true,
+}
+
+// AnalyzeFiles computes file tree statistics from the entries of a
+// recursive git tree.
+func AnalyzeFiles(tree []github.TreeEntry) FileStats {
+	var stats FileStats
+	byExt := make(map[string]*FileGroup)
+	byDir := make(map[string]*FileGroup)
+	excluded := make(map[string]bool) // Vendored and generated directories
+
+	// Directories first, so files can be checked against them
+	sorted := append([]github.TreeEntry(nil), tree...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+	for _, entry := range sorted {
+		if entry.Type != "tree" {
+			continue
+		}
+		stats.Dirs++
+		if underAny(entry.Path, excluded) {
+			continue
+		}
+		name := path.Base(entry.Path)
+		switch {
+		case vendoredDirs[name]:
+			stats.Vendored = append(stats.Vendored, entry.Path)
+			excluded[entry.Path] = true
+		case generatedDirs[name]:
+			stats.Generated = append(stats.Generated, entry.Path)
+			excluded[entry.Path] = true
+		}
+	}
+
+	for _, entry := range sorted {
+		if entry.Type != "blob" {
+			continue
+		}
+		stats.Files++
+		stats.TotalBytes += entry.Size
+		stats.Largest = append(stats.Largest, FileInfo{Path: entry.Path, Bytes: entry.Size})
+
+		if depth := strings.Count(entry.Path, "/") + 1; depth > stats.MaxDepth {
+			stats.MaxDepth = depth
+		}
+
+		ext := strings.ToLower(path.Ext(entry.Path))
+		addToGroup(byExt, ext, "(none)", entry.Size)
+
+		dir := "(root)"
+		if i := strings.Index(entry.Path, "/"); i >= 0 {
+			dir = entry.Path[:i]
+		}
+		addToGroup(byDir, dir, "", entry.Size)
+
+		if !sourceExtensions[ext] || underAny(entry.Path, excluded) {
+			continue
+		}
+		if isTestFile(entry.Path) {
+			stats.TestFiles++
+		} else {
+			stats.SourceFiles++
+		}
+	}
+
+	sort.SliceStable(stats.Largest, func(i, j int) bool { return stats.Largest[i].Bytes > stats.Largest[j].Bytes })
+	if len(stats.Largest) > largestFiles {
+		stats.Largest = stats.Largest[:largestFiles]
+	}
+	stats.ByExtension = sortedGroups(byExt)
+	stats.ByDirectory = sortedGroups(byDir)
+	if stats.SourceFiles > 0 {
+		stats.TestRatio = float64(stats.TestFiles) / float64(stats.SourceFiles)
+	}
+	return stats
+}
+
+// isTestFile reports whether p looks like a test by its name or by sitting
+// in a test directory.
+func isTestFile(p string) bool {
+	base := path.Base(p)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	switch {
+	case strings.HasSuffix(stem, "_test"), strings.HasSuffix(stem, "_spec"),
+		strings.HasSuffix(stem, ".test"), strings.HasSuffix(stem, ".spec"),
+		strings.HasPrefix(stem, "test_"),
+		strings.HasSuffix(stem, "Test"), strings.HasSuffix(stem, "Tests"):
+		return true
+	}
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		if testDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// underAny reports whether p lies inside one of dirs.
+func underAny(p string, dirs map[string]bool) bool {
+	for dir := path.Dir(p); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if dirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+func addToGroup(groups map[string]*FileGroup, name, fallback string, size int) {
+	if name == "" {
+		name = fallback
+	}
+	g, ok := groups[name]
+	if !ok {
+		g = &FileGroup{Name: name}
+		groups[name] = g
+	}
+	g.Files++
+	g.Bytes += size
+}
+
+// sortedGroups returns groups by size, biggest first.
+func sortedGroups(groups map[string]*FileGroup) []FileGroup {
+	out := make([]FileGroup, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Bytes != out[j].Bytes {
+			return out[i].Bytes > out[j].Bytes
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
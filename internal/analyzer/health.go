@@ -1,25 +1,100 @@
 package analyzer
 
-import "github.com/agnivo988/Repo-lyzer/internal/github"
+import (
+	"time"
 
-func CalculateHealth(repo *github.Repo, commits []github.Commit) int {
-	score := 50
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
 
-	if repo.Description != "" {
-		score += 10
+// HealthInput is the analysis data health rules are evaluated against.
+type HealthInput struct {
+	Repo         *github.Repo
+	Commits      []github.Commit
+	Contributors []github.Contributor
+	Issues       IssueStats
+	PullRequests PRStats
+	Releases     ReleaseStats
+	BusFactor    BusFactorResult
+	FetchErrors  map[string]error // Failed fetches, see github.RepoData.Errors
+}
+
+// HealthMetrics describes the metrics health rules can refer to.
+var HealthMetrics = map[string]string{
+	"stars":                  "Stargazers",
+	"forks":                  "Forks",
+	"has_description":        "1 when the repository has a description",
+	"has_license":            "1 when GitHub detected a license",
+	"archived":               "1 when the repository is archived",
+	"commits":                "Commits in the analysis window",
+	"contributors":           "Contributors",
+	"days_since_push":        "Days since the last push",
+	"open_issues":            "Open issues, without pull requests",
+	"issue_response_score":   "Maintainer responsiveness to issues, 0-10",
+	"issue_first_response_h": "Median hours to the first maintainer response",
+	"issue_close_h":          "Median hours to close an issue",
+	"pr_merge_ratio":         "Share of closed pull requests that were merged, 0-1",
+	"pr_merge_h":             "Median hours to merge a pull request",
+	"releases":               "Published releases, or tags without releases",
+	"days_since_release":     "Days since the latest release",
+	"bus_factor":             "Bus factor",
+}
+
+// Metrics returns the values of HealthMetrics for in. Metrics without data,
+// such as the merge time of a repository without merged pull requests or
+// the commit count when fetching the commits failed, are left out and
+// never satisfy a rule.
+func (in HealthInput) Metrics() map[string]float64 {
+	m := map[string]float64{
+		"stars":                float64(in.Repo.Stars),
+		"forks":                float64(in.Repo.Forks),
+		"has_description":      boolMetric(in.Repo.Description != ""),
+		"has_license":          boolMetric(in.Repo.License != nil),
+		"archived":             boolMetric(in.Repo.Archived),
+		"commits":              float64(len(in.Commits)),
+		"contributors":         float64(len(in.Contributors)),
+		"open_issues":          float64(in.Issues.Open),
+		"issue_response_score": float64(in.Issues.ResponseScore()),
+		"releases":             float64(in.Releases.Count),
+	}
+
+	if !in.Repo.PushedAt.IsZero() {
+		m["days_since_push"] = time.Since(in.Repo.PushedAt).Hours() / 24
+	}
+	if in.Issues.Responded > 0 {
+		m["issue_first_response_h"] = in.Issues.MedianFirstResponse.Hours()
 	}
-	if repo.Stars > 50 {
-		score += 10
+	if in.Issues.Closed > 0 {
+		m["issue_close_h"] = in.Issues.MedianTimeToClose.Hours()
 	}
-	if len(commits) > 10 {
-		score += 20
+	if in.PullRequests.Merged+in.PullRequests.ClosedUnmerged > 0 {
+		m["pr_merge_ratio"] = in.PullRequests.MergeRatio
 	}
-	if repo.OpenIssues < 20 {
-		score += 10
+	if in.PullRequests.Merged > 0 {
+		m["pr_merge_h"] = in.PullRequests.MedianTimeToMerge.Hours()
 	}
+	if in.Releases.DaysSinceLast >= 0 {
+		m["days_since_release"] = float64(in.Releases.DaysSinceLast)
+	}
+	if in.BusFactor.Count > 0 {
+		m["bus_factor"] = float64(in.BusFactor.Count)
+	}
+	for metric := range m {
+		if _, err := failedSource(metric, in.FetchErrors); err != nil {
+			delete(m, metric)
+		}
+	}
+	return m
+}
 
-	if score > 100 {
-		score = 100
+func boolMetric(b bool) float64 {
+	if b {
+		return 1
 	}
-	return score
+	return 0
+}
+
+// CalculateHealth scores a repository from 0 to 100 with model and
+// explains the score rule by rule.
+func CalculateHealth(in HealthInput, model HealthModel) HealthReport {
+	return model.Score(in.Metrics())
 }
@@ -0,0 +1,182 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// AgeBucket counts open issues whose age falls in a range.
+type AgeBucket struct {
+	Label string
+	Count int
+}
+
+// backlogBuckets are the upper age limits of the backlog distribution;
+// older issues fall into a final "over 1 year" bucket.
+var backlogBuckets = []struct {
+	label string
+	limit time.Duration
+}{
+	{"< 1 week", 7 * 24 * time.Hour},
+	{"1-4 weeks", 30 * 24 * time.Hour},
+	{"1-3 months", 90 * 24 * time.Hour},
+	{"3-12 months", 365 * 24 * time.Hour},
+}
+
+// IssueStats describes how responsive maintainers are to issues.
+type IssueStats struct {
+	Open   int // Open issues, regardless of age
+	Opened int // Issues opened in the window
+	Closed int // Issues opened and closed in the window
+
+	// First response is the first comment by a maintainer other than the
+	// author, on issues opened in the window by non-maintainers.
+	MedianFirstResponse time.Duration
+	P90FirstResponse    time.Duration
+	Responded           int
+	Unanswered          int // Still open without a maintainer response
+	Unknown             int // Opened before the oldest comment of a truncated list
+
+	MedianTimeToClose time.Duration
+	MedianBacklogAge  time.Duration
+	Backlog           []AgeBucket // Age distribution of the open issues
+
+	Health string
+}
+
+// AnalyzeIssues computes responsiveness statistics over the issues opened
+// in the last days days; a days value of zero or less uses all of them.
+// issues should hold every open issue for the backlog distribution, and
+// comments the issue comments of the window, newest first. When the
+// max-items cap cut the comments short, commentsTruncated is set and issues
+// opened before the oldest comment count as Unknown: their first response
+// may be among the comments that were dropped.
+func AnalyzeIssues(issues []github.Issue, comments []github.IssueComment, days int, commentsTruncated bool) IssueStats {
+	var since time.Time
+	if days > 0 {
+		since = time.Now().AddDate(0, 0, -days)
+	}
+
+	// Earliest maintainer comment per issue that isn't the author's reply
+	authors := make(map[int]string, len(issues))
+	for _, issue := range issues {
+		authors[issue.Number] = issue.User.Login
+	}
+	firstResponse := make(map[int]time.Time)
+	var oldest time.Time
+	for _, c := range comments {
+		if oldest.IsZero() || c.CreatedAt.Before(oldest) {
+			oldest = c.CreatedAt
+		}
+		n := c.IssueNumber()
+		if !IsMaintainer(c.AuthorAssociation) || c.User.Login == authors[n] {
+			continue
+		}
+		if first, ok := firstResponse[n]; !ok || c.CreatedAt.Before(first) {
+			firstResponse[n] = c.CreatedAt
+		}
+	}
+
+	stats := IssueStats{}
+	var toRespond, toClose, ages []time.Duration
+	now := time.Now()
+
+	for _, issue := range issues {
+		if issue.State == "open" {
+			stats.Open++
+			ages = append(ages, now.Sub(issue.CreatedAt))
+		}
+		if issue.CreatedAt.Before(since) {
+			continue
+		}
+
+		stats.Opened++
+		if issue.ClosedAt != nil {
+			stats.Closed++
+			toClose = append(toClose, issue.ClosedAt.Sub(issue.CreatedAt))
+		}
+
+		if IsMaintainer(issue.AuthorAssociation) {
+			continue
+		}
+		if commentsTruncated && issue.CreatedAt.Before(oldest) {
+			stats.Unknown++
+			continue
+		}
+		if first, ok := firstResponse[issue.Number]; ok {
+			toRespond = append(toRespond, first.Sub(issue.CreatedAt))
+		} else if issue.State == "open" {
+			stats.Unanswered++
+		}
+	}
+
+	stats.Responded = len(toRespond)
+	stats.MedianFirstResponse = median(toRespond)
+	stats.P90FirstResponse = percentile(toRespond, 90)
+	stats.MedianTimeToClose = median(toClose)
+	stats.MedianBacklogAge = median(ages)
+	stats.Backlog = backlogDistribution(ages)
+	stats.Health = issueHealth(stats)
+
+	return stats
+}
+
+// backlogDistribution sorts the ages of open issues into backlogBuckets.
+func backlogDistribution(ages []time.Duration) []AgeBucket {
+	buckets := make([]AgeBucket, len(backlogBuckets)+1)
+	for i, b := range backlogBuckets {
+		buckets[i].Label = b.label
+	}
+	buckets[len(backlogBuckets)].Label = "> 1 year"
+
+	for _, age := range ages {
+		i := 0
+		for i < len(backlogBuckets) && age >= backlogBuckets[i].limit {
+			i++
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
+
+// ResponseScore rates maintainer responsiveness from 0 to 10 for the
+// health score. Repositories without issues to respond to get full marks.
+func (s IssueStats) ResponseScore() int {
+	if s.Responded+s.Unanswered == 0 {
+		return 10
+	}
+
+	answered := float64(s.Responded) / float64(s.Responded+s.Unanswered)
+	switch {
+	case s.Responded == 0:
+		return 0
+	case s.MedianFirstResponse <= 2*24*time.Hour && answered >= 0.75:
+		return 10
+	case s.MedianFirstResponse <= 7*24*time.Hour && answered >= 0.5:
+		return 5
+	default:
+		return 2
+	}
+}
+
+// issueHealth summarizes stats in one line for the recruiter summary.
+func issueHealth(s IssueStats) string {
+	if s.Responded+s.Unanswered == 0 {
+		return "No recent issues from users"
+	}
+	if s.Responded == 0 {
+		return fmt.Sprintf("Unresponsive (%d issues without a maintainer reply)", s.Unanswered)
+	}
+
+	label := "Slow"
+	switch s.ResponseScore() {
+	case 10:
+		label = "Responsive"
+	case 5:
+		label = "Moderate"
+	}
+	return fmt.Sprintf("%s (median first response %s, p90 %s)",
+		label, FormatDuration(s.MedianFirstResponse), FormatDuration(s.P90FirstResponse))
+}
@@ -0,0 +1,193 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyRule is a threshold a repository must meet, e.g. bus_factor >= 2.
+type PolicyRule struct {
+	Name        string  `json:"name,omitempty" yaml:"name,omitempty"` // Defaults to the metric
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Metric      string  `json:"metric" yaml:"metric"`
+	Op          string  `json:"op,omitempty" yaml:"op,omitempty"` // >, >=, <, <=, == or !=; defaults to >=
+	Threshold   float64 `json:"threshold" yaml:"threshold"`
+}
+
+// Policy is a set of thresholds for a quality gate. Unlike a health model
+// it doesn't score: every rule must pass.
+type Policy struct {
+	Rules []PolicyRule `json:"rules" yaml:"rules"`
+}
+
+// PolicyMetrics describes the metrics policy rules can refer to: the
+// health metrics and the scores computed from them.
+var PolicyMetrics = func() map[string]string {
+	m := map[string]string{
+		"health_score":      "Health score, 0-100",
+		"maturity_score":    "Maturity score, 0-100",
+		"security_high":     "High severity security findings",
+		"security_findings": "Security findings of any severity",
+		"test_ratio":        "Test files per source file",
+		"activity_trend":    "Fitted change in weekly commits over the window, relative to the mean",
+	}
+	for name, desc := range HealthMetrics {
+		m[name] = desc
+	}
+	return m
+}()
+
+// PolicyReport is the outcome of a policy for one repository.
+type PolicyReport struct {
+	Repo    string
+	Results []RuleResult // In policy order; Points is unused
+}
+
+// Passed reports whether every rule passed.
+func (r PolicyReport) Passed() bool {
+	return r.Failures() == 0
+}
+
+// Failures counts the rules that didn't pass, skipped rules included.
+func (r PolicyReport) Failures() int {
+	n := 0
+	for _, result := range r.Results {
+		if !result.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// Skips counts the rules that were skipped, see Check.
+func (r PolicyReport) Skips() int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Skipped {
+			n++
+		}
+	}
+	return n
+}
+
+// securityMetrics are the policy metrics counted from the security
+// checks.
+var securityMetrics = map[string]bool{"security_high": true, "security_findings": true}
+
+// LoadPolicy reads a YAML or JSON (by .json extension) policy file.
+func LoadPolicy(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	var policy Policy
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &policy)
+	} else {
+		err = yaml.Unmarshal(data, &policy)
+	}
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return Policy{}, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// Validate checks that the policy has rules and that every rule has a
+// known metric and a valid operator.
+func (p Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("no rules")
+	}
+	for _, rule := range p.Rules {
+		if _, ok := PolicyMetrics[rule.Metric]; !ok {
+			names := make([]string, 0, len(PolicyMetrics))
+			for name := range PolicyMetrics {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("rule %q: unknown metric %q (known: %s)", rule.name(), rule.Metric, strings.Join(names, ", "))
+		}
+		if _, ok := compare(rule.Op, 0, 0); !ok {
+			return fmt.Errorf("rule %q: unknown op %q", rule.name(), rule.Op)
+		}
+	}
+	return nil
+}
+
+func (r PolicyRule) name() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Metric
+}
+
+// Check evaluates the policy against an analysis. A rule on a metric
+// without data fails. Rules on the security metrics are skipped, which
+// doesn't pass either, when security checks were skipped: the findings
+// they count are incomplete.
+func (p Policy) Check(a Analysis) PolicyReport {
+	metrics := a.PolicyMetrics()
+	report := PolicyReport{Repo: a.Repo.FullName}
+	for _, rule := range p.Rules {
+		health := HealthRule{
+			Name:        rule.name(),
+			Description: rule.Description,
+			Metric:      rule.Metric,
+			Op:          rule.Op,
+			Threshold:   rule.Threshold,
+		}
+		if securityMetrics[rule.Metric] && len(a.Security.Skipped) > 0 {
+			report.Results = append(report.Results, RuleResult{
+				Rule:    health,
+				Skipped: true,
+				Reason:  rule.Metric + ": " + a.Security.SkipSummary(),
+			})
+			continue
+		}
+		report.Results = append(report.Results, evaluate(health, metrics))
+	}
+	return report
+}
+
+// PolicyMetrics returns the values of PolicyMetrics for the analysis.
+// Metrics whose fetches failed are left out, see MetricWarnings.
+func (a Analysis) PolicyMetrics() map[string]float64 {
+	m := HealthInput{
+		Repo:         a.Repo,
+		Commits:      a.Commits,
+		Contributors: a.Contributors,
+		Issues:       a.Issues,
+		PullRequests: a.PullRequests,
+		Releases:     a.Releases,
+		BusFactor:    a.BusFactor,
+		FetchErrors:  a.FetchErrors,
+	}.Metrics()
+
+	m["health_score"] = float64(a.HealthScore)
+	m["maturity_score"] = float64(a.MaturityScore)
+	if a.FileTree != nil {
+		m["security_high"] = float64(a.Security.Count(SeverityHigh))
+		m["security_findings"] = float64(len(a.Security.Findings))
+	}
+	if a.Files.SourceFiles > 0 {
+		m["test_ratio"] = a.Files.TestRatio
+	}
+	if a.Trend.Direction != TrendUnknown {
+		m["activity_trend"] = a.Trend.Change
+	}
+	for _, w := range a.MetricWarnings() {
+		delete(m, w.Metric)
+	}
+	return m
+}
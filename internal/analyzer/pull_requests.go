@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// PRStats describes how a repository handles pull requests.
+type PRStats struct {
+	Total          int
+	Open           int
+	Merged         int
+	ClosedUnmerged int
+
+	MedianTimeToMerge       time.Duration
+	MedianTimeToFirstReview time.Duration
+	ReviewedSample          int // Pull requests the review latency is based on
+
+	// MergeRatio is the share of closed pull requests that were merged.
+	MergeRatio float64
+
+	// External pull requests come from authors without write access.
+	ExternalClosed     int
+	ExternalMerged     int
+	ExternalAcceptance float64 // Share of closed external pull requests that were merged
+
+	Health string
+}
+
+// maintainerAssociations are the author associations of people with
+// write access to a repository.
+var maintainerAssociations = map[string]bool{
+	"OWNER":        true,
+	"MEMBER":       true,
+	"COLLABORATOR": true,
+}
+
+// IsMaintainer reports whether an author association grants write access.
+func IsMaintainer(association string) bool {
+	return maintainerAssociations[association]
+}
+
+// AnalyzePullRequests computes merge and review statistics. reviews holds
+// the reviews of a sample of the pull requests keyed by number; only
+// reviews by someone other than the author count as a first review.
+func AnalyzePullRequests(prs []github.PullRequest, reviews map[int][]github.Review) PRStats {
+	stats := PRStats{Total: len(prs)}
+
+	var toMerge, toReview []time.Duration
+	for _, pr := range prs {
+		external := !IsMaintainer(pr.AuthorAssociation)
+
+		switch {
+		case pr.Merged():
+			stats.Merged++
+			toMerge = append(toMerge, pr.MergedAt.Sub(pr.CreatedAt))
+			if external {
+				stats.ExternalClosed++
+				stats.ExternalMerged++
+			}
+		case pr.State == "closed":
+			stats.ClosedUnmerged++
+			if external {
+				stats.ExternalClosed++
+			}
+		default:
+			stats.Open++
+		}
+
+		if first, ok := firstReview(pr, reviews[pr.Number]); ok {
+			toReview = append(toReview, first.Sub(pr.CreatedAt))
+		}
+	}
+
+	stats.MedianTimeToMerge = median(toMerge)
+	stats.MedianTimeToFirstReview = median(toReview)
+	stats.ReviewedSample = len(toReview)
+
+	if closed := stats.Merged + stats.ClosedUnmerged; closed > 0 {
+		stats.MergeRatio = float64(stats.Merged) / float64(closed)
+	}
+	if stats.ExternalClosed > 0 {
+		stats.ExternalAcceptance = float64(stats.ExternalMerged) / float64(stats.ExternalClosed)
+	}
+
+	stats.Health = prHealth(stats)
+	return stats
+}
+
+// firstReview returns when someone other than the author first reviewed pr.
+func firstReview(pr github.PullRequest, reviews []github.Review) (time.Time, bool) {
+	var first time.Time
+	for _, r := range reviews {
+		if r.User.Login == pr.User.Login || r.SubmittedAt.IsZero() {
+			continue
+		}
+		if first.IsZero() || r.SubmittedAt.Before(first) {
+			first = r.SubmittedAt
+		}
+	}
+	return first, !first.IsZero()
+}
+
+// prHealth summarizes stats in one line for the recruiter summary.
+func prHealth(s PRStats) string {
+	if s.Merged+s.ClosedUnmerged == 0 {
+		return "No closed pull requests"
+	}
+
+	label := "Slow"
+	switch {
+	case s.MergeRatio >= 0.6 && s.MedianTimeToMerge <= 7*24*time.Hour:
+		label = "Healthy"
+	case s.MedianTimeToMerge <= 30*24*time.Hour:
+		label = "Moderate"
+	}
+
+	return fmt.Sprintf("%s (%.0f%% merged, median %s to merge)",
+		label, s.MergeRatio*100, FormatDuration(s.MedianTimeToMerge))
+}
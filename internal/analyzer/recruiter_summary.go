@@ -4,7 +4,8 @@ type RecruiterSummary struct {
 	RepoName        string
 	Stars           int
 	Forks           int
-	CommitsLastYear int
+	Commits         int
+	Days            int // Analysis window of Commits
 	Contributors    int
 
 	MaturityScore   int
@@ -16,6 +17,7 @@ type RecruiterSummary struct {
 	IssueHealth     string
 	PRHealth        string
 	ActivityLevel   string
+	Trend           string
 }
 
 
@@ -40,7 +42,7 @@ func BuildRecruiterSummary(
 		RepoName:        repoName,
 		Stars:           stars,
 		Forks:           forks,
-		CommitsLastYear: commits,
+		Commits:         commits,
 		Contributors:    contributors,
 		MaturityScore:   maturityScore,
 		MaturityLevel:   maturityLevel,
@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// ReleaseStats summarizes how often and how recently a repository ships.
+type ReleaseStats struct {
+	Count         int  // Published releases, or tags when there are none
+	FromTags      bool // Count and LatestVersion come from git tags
+	LatestVersion string
+	LatestAt      time.Time // Zero when only tags are known
+	DaysSinceLast int       // -1 when unknown
+	CadenceDays   float64   // Median days between releases, 0 with fewer than two
+	Cadence       string
+}
+
+// HasReleases reports whether the repository ships versions at all.
+func (s ReleaseStats) HasReleases() bool {
+	return s.Count > 0
+}
+
+// AnalyzeReleases computes release statistics. Drafts and prereleases are
+// ignored. When a repository has no published releases its tags are used
+// instead, which gives a count and the highest version but no dates.
+func AnalyzeReleases(releases []github.Release, tags []github.Tag) ReleaseStats {
+	var published []github.Release
+	for _, r := range releases {
+		if r.Published() {
+			published = append(published, r)
+		}
+	}
+
+	if len(published) == 0 {
+		stats := ReleaseStats{Count: len(tags), DaysSinceLast: -1, Cadence: "No releases"}
+		if len(tags) > 0 {
+			stats.FromTags = true
+			stats.Cadence = "Tags only"
+			stats.LatestVersion = latestTag(tags)
+		}
+		return stats
+	}
+
+	sort.Slice(published, func(i, j int) bool {
+		return published[i].PublishedAt.After(published[j].PublishedAt)
+	})
+
+	latest := published[0]
+	stats := ReleaseStats{
+		Count:         len(published),
+		LatestVersion: latest.TagName,
+		LatestAt:      latest.PublishedAt,
+		DaysSinceLast: int(time.Since(latest.PublishedAt).Hours() / 24),
+		Cadence:       "Single release",
+	}
+
+	if len(published) > 1 {
+		gaps := make([]float64, 0, len(published)-1)
+		for i := 1; i < len(published); i++ {
+			gap := published[i-1].PublishedAt.Sub(published[i].PublishedAt).Hours() / 24
+			gaps = append(gaps, gap)
+		}
+		sort.Float64s(gaps)
+		stats.CadenceDays = gaps[len(gaps)/2]
+		if len(gaps)%2 == 0 {
+			stats.CadenceDays = (gaps[len(gaps)/2-1] + gaps[len(gaps)/2]) / 2
+		}
+
+		switch {
+		case stats.CadenceDays <= 14:
+			stats.Cadence = "Frequent"
+		case stats.CadenceDays <= 60:
+			stats.Cadence = "Regular"
+		case stats.CadenceDays <= 180:
+			stats.Cadence = "Occasional"
+		default:
+			stats.Cadence = "Rare"
+		}
+	}
+
+	return stats
+}
+
+// latestTag returns the tag with the highest version number. Tags are
+// listed by name, which puts v1.10 before v1.9, so they are compared
+// numerically instead.
+func latestTag(tags []github.Tag) string {
+	best := tags[0].Name
+	for _, t := range tags[1:] {
+		if compareVersions(t.Name, best) > 0 {
+			best = t.Name
+		}
+	}
+	return best
+}
+
+// compareVersions compares the numeric parts of two version strings such
+// as "v1.10.2" and "1.9", returning -1, 0 or 1.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionParts extracts the numbers of a version string in order.
+func versionParts(v string) []int {
+	fields := strings.FieldsFunc(v, func(r rune) bool { return !unicode.IsDigit(r) })
+	parts := make([]int, 0, len(fields))
+	for _, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			continue
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
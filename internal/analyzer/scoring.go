@@ -0,0 +1,299 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HealthRule awards Points when Metric compares to Threshold with Op. A
+// Scale rule awards a share of Points instead, in proportion to how close
+// the metric gets to the threshold. Negative points make a penalty.
+type HealthRule struct {
+	Name        string  `json:"name" yaml:"name"`
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Metric      string  `json:"metric" yaml:"metric"`
+	Op          string  `json:"op,omitempty" yaml:"op,omitempty"` // >, >=, <, <=, == or !=; defaults to >=
+	Threshold   float64 `json:"threshold" yaml:"threshold"`
+	Points      float64 `json:"points" yaml:"points"`
+	Scale       bool    `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Disabled    bool    `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// HealthModel is a set of rules on top of a base score. Scores are
+// clamped to 0-100.
+type HealthModel struct {
+	Base  float64      `json:"base" yaml:"base"`
+	Rules []HealthRule `json:"rules" yaml:"rules"`
+}
+
+// RuleResult is the outcome of one rule for the score breakdown.
+type RuleResult struct {
+	Rule    HealthRule
+	Value   float64
+	Known   bool    // Whether the metric had data
+	Points  float64 // Points awarded
+	Passed  bool
+	Skipped bool // Not evaluated for incomplete data, policy rules only
+	Reason  string
+}
+
+// HealthReport is a health score with the breakdown that explains it.
+type HealthReport struct {
+	Score int
+	Base  float64
+	Rules []RuleResult
+}
+
+// DefaultHealthModel returns the built-in scoring rules.
+func DefaultHealthModel() HealthModel {
+	return HealthModel{
+		Base: 50,
+		Rules: []HealthRule{
+			{Name: "description", Description: "Repository is described", Metric: "has_description", Op: ">=", Threshold: 1, Points: 10},
+			{Name: "popularity", Description: "More than 50 stars", Metric: "stars", Op: ">", Threshold: 50, Points: 10},
+			{Name: "activity", Description: "More than 10 commits in the window", Metric: "commits", Op: ">", Threshold: 10, Points: 20},
+			{Name: "issue response", Description: "Maintainers respond to issues quickly", Metric: "issue_response_score", Op: ">=", Threshold: 10, Points: 10, Scale: true},
+		},
+	}
+}
+
+// HealthModelEnv names a health model file that replaces the default
+// location.
+const HealthModelEnv = "REPOLYZER_HEALTH_CONFIG"
+
+// DefaultHealthModelPath returns where a health model file is looked up
+// when HealthModelEnv isn't set: health.yaml in the user config directory.
+func DefaultHealthModelPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "repo-lyzer", "health.yaml")
+}
+
+// HealthModelFromEnv loads the health model file named by HealthModelEnv,
+// or the one at DefaultHealthModelPath when it exists, and otherwise
+// returns the default model.
+func HealthModelFromEnv() (HealthModel, error) {
+	if path := os.Getenv(HealthModelEnv); path != "" {
+		return LoadHealthModel(path)
+	}
+	if path := DefaultHealthModelPath(); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return LoadHealthModel(path)
+		}
+	}
+	return DefaultHealthModel(), nil
+}
+
+// LoadHealthModel reads a YAML or JSON (by .json extension) health model
+// file and merges it into the default model: rules are matched by name,
+// so a file can change the weight or threshold of a built-in rule,
+// disable it, or add rules of its own. A base in the file replaces the
+// default base.
+func LoadHealthModel(path string) (HealthModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return HealthModel{}, fmt.Errorf("failed to read health model: %w", err)
+	}
+
+	var file struct {
+		Base  *float64       `json:"base" yaml:"base"`
+		Rules []ruleOverride `json:"rules" yaml:"rules"`
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return HealthModel{}, fmt.Errorf("failed to parse health model %s: %w", path, err)
+	}
+
+	model := DefaultHealthModel()
+	if file.Base != nil {
+		model.Base = *file.Base
+	}
+	for _, rule := range file.Rules {
+		model.Rules = mergeRule(model.Rules, rule)
+	}
+
+	if err := model.Validate(); err != nil {
+		return HealthModel{}, fmt.Errorf("invalid health model %s: %w", path, err)
+	}
+	return model, nil
+}
+
+// ruleOverride is a rule as written in a health model file. Fields left
+// out keep the values of the built-in rule of the same name.
+type ruleOverride struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Metric      string   `json:"metric" yaml:"metric"`
+	Op          string   `json:"op" yaml:"op"`
+	Threshold   *float64 `json:"threshold" yaml:"threshold"`
+	Points      *float64 `json:"points" yaml:"points"`
+	Scale       *bool    `json:"scale" yaml:"scale"`
+	Disabled    bool     `json:"disabled" yaml:"disabled"`
+}
+
+// mergeRule applies o to the rule with the same name, or appends it as a
+// new rule.
+func mergeRule(rules []HealthRule, o ruleOverride) []HealthRule {
+	i := 0
+	for i < len(rules) && rules[i].Name != o.Name {
+		i++
+	}
+	if i == len(rules) {
+		rules = append(rules, HealthRule{Name: o.Name})
+	}
+
+	rule := &rules[i]
+	if o.Description != "" {
+		rule.Description = o.Description
+	}
+	if o.Metric != "" {
+		rule.Metric = o.Metric
+	}
+	if o.Op != "" {
+		rule.Op = o.Op
+	}
+	if o.Threshold != nil {
+		rule.Threshold = *o.Threshold
+	}
+	if o.Points != nil {
+		rule.Points = *o.Points
+	}
+	if o.Scale != nil {
+		rule.Scale = *o.Scale
+	}
+	rule.Disabled = o.Disabled
+	return rules
+}
+
+// Validate checks that every rule has a name, a known metric and a valid
+// operator.
+func (m HealthModel) Validate() error {
+	for _, rule := range m.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule without a name")
+		}
+		if _, ok := HealthMetrics[rule.Metric]; !ok {
+			return fmt.Errorf("rule %q: unknown metric %q (known: %s)", rule.Name, rule.Metric, strings.Join(metricNames(), ", "))
+		}
+		if _, ok := compare(rule.Op, 0, 0); !ok {
+			return fmt.Errorf("rule %q: unknown op %q", rule.Name, rule.Op)
+		}
+	}
+	return nil
+}
+
+// Score evaluates the model's rules against metrics.
+func (m HealthModel) Score(metrics map[string]float64) HealthReport {
+	report := HealthReport{Base: m.Base}
+	total := m.Base
+
+	for _, rule := range m.Rules {
+		if rule.Disabled {
+			continue
+		}
+		result := evaluate(rule, metrics)
+		total += result.Points
+		report.Rules = append(report.Rules, result)
+	}
+
+	report.Score = int(math.Round(math.Max(0, math.Min(100, total))))
+	return report
+}
+
+// evaluate applies one rule.
+func evaluate(rule HealthRule, metrics map[string]float64) RuleResult {
+	result := RuleResult{Rule: rule}
+	op := rule.Op
+	if op == "" {
+		op = ">="
+	}
+
+	value, ok := metrics[rule.Metric]
+	if !ok {
+		result.Reason = fmt.Sprintf("%s: no data", rule.Metric)
+		return result
+	}
+	result.Value, result.Known = value, true
+	result.Passed, _ = compare(op, value, rule.Threshold)
+
+	switch {
+	case result.Passed:
+		result.Points = rule.Points
+	case rule.Scale:
+		result.Points = rule.Points * scaleShare(op, value, rule.Threshold)
+	}
+
+	if result.Passed {
+		result.Reason = fmt.Sprintf("%s = %s %s %s", rule.Metric, formatMetric(value), op, formatMetric(rule.Threshold))
+	} else {
+		result.Reason = fmt.Sprintf("%s = %s, needs %s %s", rule.Metric, formatMetric(value), op, formatMetric(rule.Threshold))
+	}
+	return result
+}
+
+// compare applies op, reporting false for ok when op is unknown. An empty
+// op means >=.
+func compare(op string, value, threshold float64) (passed, ok bool) {
+	switch op {
+	case ">", "gt":
+		return value > threshold, true
+	case ">=", "gte", "":
+		return value >= threshold, true
+	case "<", "lt":
+		return value < threshold, true
+	case "<=", "lte":
+		return value <= threshold, true
+	case "==", "eq":
+		return value == threshold, true
+	case "!=", "ne":
+		return value != threshold, true
+	}
+	return false, false
+}
+
+// scaleShare is how far value got towards the threshold, from 0 to 1.
+// For lower-is-better rules it is threshold/value instead.
+func scaleShare(op string, value, threshold float64) float64 {
+	var share float64
+	switch op {
+	case ">", ">=", "gt", "gte", "":
+		if threshold > 0 {
+			share = value / threshold
+		}
+	case "<", "<=", "lt", "lte":
+		if value > 0 {
+			share = threshold / value
+		}
+	}
+	return math.Max(0, math.Min(1, share))
+}
+
+// formatMetric prints whole numbers without decimals.
+func formatMetric(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func metricNames() []string {
+	names := make([]string, 0, len(HealthMetrics))
+	for name := range HealthMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,254 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadHealthModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+		check   func(t *testing.T, m HealthModel)
+	}{
+		{
+			name: "empty file keeps the defaults",
+			file: "health.yaml",
+			check: func(t *testing.T, m HealthModel) {
+				want := DefaultHealthModel()
+				if m.Base != want.Base || len(m.Rules) != len(want.Rules) {
+					t.Errorf("got base %v with %d rules, want %v with %d", m.Base, len(m.Rules), want.Base, len(want.Rules))
+				}
+			},
+		},
+		{
+			name: "yaml overrides a built-in rule",
+			file: "health.yaml",
+			content: `base: 40
+rules:
+  - name: popularity
+    threshold: 500
+`,
+			check: func(t *testing.T, m HealthModel) {
+				if m.Base != 40 {
+					t.Errorf("base = %v, want 40", m.Base)
+				}
+				rule := findRule(t, m, "popularity")
+				if rule.Threshold != 500 {
+					t.Errorf("threshold = %v, want 500", rule.Threshold)
+				}
+				// Fields left out keep the built-in values
+				if rule.Metric != "stars" || rule.Op != ">" || rule.Points != 10 {
+					t.Errorf("rule = %+v, want the built-in metric, op and points", rule)
+				}
+				if len(m.Rules) != len(DefaultHealthModel().Rules) {
+					t.Errorf("got %d rules, want the %d built-in ones", len(m.Rules), len(DefaultHealthModel().Rules))
+				}
+			},
+		},
+		{
+			name:    "json adds a rule and disables another",
+			file:    "health.json",
+			content: `{"rules": [{"name": "license", "metric": "has_license", "threshold": 1, "points": 5}, {"name": "activity", "disabled": true}]}`,
+			check: func(t *testing.T, m HealthModel) {
+				if m.Base != DefaultHealthModel().Base {
+					t.Errorf("base = %v, want the default", m.Base)
+				}
+				license := findRule(t, m, "license")
+				if license.Metric != "has_license" || license.Threshold != 1 || license.Points != 5 {
+					t.Errorf("license rule = %+v", license)
+				}
+				if m.Rules[len(m.Rules)-1].Name != "license" {
+					t.Error("new rule not appended after the built-in ones")
+				}
+				if !findRule(t, m, "activity").Disabled {
+					t.Error("activity rule not disabled")
+				}
+			},
+		},
+		{
+			name:    "zero points override",
+			file:    "health.yml",
+			content: "rules:\n  - name: issue response\n    points: 0\n    scale: false\n",
+			check: func(t *testing.T, m HealthModel) {
+				rule := findRule(t, m, "issue response")
+				if rule.Points != 0 || rule.Scale {
+					t.Errorf("rule = %+v, want 0 points without scale", rule)
+				}
+			},
+		},
+		{
+			name:    "uppercase json extension",
+			file:    "HEALTH.JSON",
+			content: `{"base": 0}`,
+			check: func(t *testing.T, m HealthModel) {
+				if m.Base != 0 {
+					t.Errorf("base = %v, want 0", m.Base)
+				}
+			},
+		},
+		{
+			name:    "new rule without a metric",
+			file:    "health.yaml",
+			content: "rules:\n  - name: mystery\n    points: 5\n",
+			wantErr: `rule "mystery": unknown metric ""`,
+		},
+		{
+			name:    "unknown metric",
+			file:    "health.yaml",
+			content: "rules:\n  - name: popularity\n    metric: likes\n",
+			wantErr: `rule "popularity": unknown metric "likes"`,
+		},
+		{
+			name:    "unknown op",
+			file:    "health.yaml",
+			content: "rules:\n  - name: popularity\n    op: '=>'\n",
+			wantErr: `rule "popularity": unknown op "=>"`,
+		},
+		{
+			name:    "rule without a name",
+			file:    "health.yaml",
+			content: "rules:\n  - metric: stars\n",
+			wantErr: "rule without a name",
+		},
+		{
+			name:    "malformed yaml",
+			file:    "health.yaml",
+			content: "rules: [",
+			wantErr: "failed to parse health model",
+		},
+		{
+			name:    "yaml in a json file",
+			file:    "health.json",
+			content: "base: 10\n",
+			wantErr: "failed to parse health model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			m, err := LoadHealthModel(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadHealthModel: %v", err)
+			}
+			tt.check(t, m)
+		})
+	}
+}
+
+func TestLoadHealthModelMissingFile(t *testing.T) {
+	_, err := LoadHealthModel(filepath.Join(t.TempDir(), "health.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "failed to read health model") {
+		t.Errorf("err = %v, want a read error", err)
+	}
+}
+
+func TestLoadHealthModelKeepsDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "health.yaml")
+	if err := os.WriteFile(path, []byte("rules:\n  - name: popularity\n    points: 99\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadHealthModel(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := findRule(t, DefaultHealthModel(), "popularity").Points; got != 10 {
+		t.Errorf("loading a model changed the default popularity points to %v", got)
+	}
+}
+
+func TestHealthModelScore(t *testing.T) {
+	model := HealthModel{
+		Base: 50,
+		Rules: []HealthRule{
+			{Name: "stars", Metric: "stars", Op: ">", Threshold: 100, Points: 20},
+			{Name: "response", Metric: "issue_response_score", Threshold: 10, Points: 10, Scale: true},
+			{Name: "merge time", Metric: "pr_merge_h", Op: "<=", Threshold: 24, Points: 10, Scale: true},
+			{Name: "archived", Metric: "archived", Op: "==", Threshold: 1, Points: -60},
+			{Name: "off", Metric: "forks", Threshold: 0, Points: 100, Disabled: true},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		metrics    map[string]float64
+		wantScore  int
+		wantPoints []float64
+		wantKnown  []bool
+	}{
+		{
+			name:       "no data",
+			metrics:    map[string]float64{},
+			wantScore:  50,
+			wantPoints: []float64{0, 0, 0, 0},
+			wantKnown:  []bool{false, false, false, false},
+		},
+		{
+			name:       "every rule passes",
+			metrics:    map[string]float64{"stars": 101, "issue_response_score": 10, "pr_merge_h": 12, "archived": 0, "forks": 1},
+			wantScore:  90,
+			wantPoints: []float64{20, 10, 10, 0},
+			wantKnown:  []bool{true, true, true, true},
+		},
+		{
+			name:       "scaled share",
+			metrics:    map[string]float64{"stars": 100, "issue_response_score": 4, "pr_merge_h": 48, "archived": 0},
+			wantScore:  59,
+			wantPoints: []float64{0, 4, 5, 0},
+			wantKnown:  []bool{true, true, true, true},
+		},
+		{
+			name:       "clamped at zero",
+			metrics:    map[string]float64{"archived": 1},
+			wantScore:  0,
+			wantPoints: []float64{0, 0, 0, -60},
+			wantKnown:  []bool{false, false, false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := model.Score(tt.metrics)
+			if report.Score != tt.wantScore {
+				t.Errorf("score = %d, want %d", report.Score, tt.wantScore)
+			}
+			if len(report.Rules) != len(tt.wantPoints) {
+				t.Fatalf("got %d rule results, want %d without the disabled rule", len(report.Rules), len(tt.wantPoints))
+			}
+			for i, r := range report.Rules {
+				if r.Points != tt.wantPoints[i] {
+					t.Errorf("%s: points = %v, want %v", r.Rule.Name, r.Points, tt.wantPoints[i])
+				}
+				if r.Known != tt.wantKnown[i] {
+					t.Errorf("%s: known = %v, want %v", r.Rule.Name, r.Known, tt.wantKnown[i])
+				}
+			}
+		})
+	}
+}
+
+func findRule(t *testing.T, m HealthModel, name string) HealthRule {
+	t.Helper()
+	for _, rule := range m.Rules {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	t.Fatalf("no rule named %q", name)
+	return HealthRule{}
+}
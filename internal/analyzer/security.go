@@ -1 +1,476 @@
-package analyzer
\ No newline at end of file
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Finding severities, most severe first.
+const (
+	SeverityHigh   = "High"
+	SeverityMedium = "Medium"
+	SeverityLow    = "Low"
+)
+
+// SecurityCheck is one of the hygiene checks AnalyzeSecurity runs.
+type SecurityCheck struct {
+	ID   string
+	Name string
+}
+
+// SecurityChecks are the checks AnalyzeSecurity runs, in report order.
+var SecurityChecks = []SecurityCheck{
+	{ID: "security-policy", Name: "Security policy"},
+	{ID: "committed-secret", Name: "Committed secrets"},
+	{ID: "missing-lockfile", Name: "Dependency lockfiles"},
+	{ID: "dependency-updates", Name: "Automated dependency updates"},
+	{ID: "unpinned-action", Name: "Pinned GitHub Actions"},
+	{ID: "workflow-permissions", Name: "Workflow token permissions"},
+}
+
+// SecurityFinding is a problem found by a security check.
+type SecurityFinding struct {
+	Check       string // SecurityCheck.ID
+	Severity    string
+	Title       string
+	Path        string // File the finding is about, "" for the repository
+	Remediation string
+}
+
+// SkippedCheck is a security check that wasn't run because its input was
+// missing or incomplete.
+type SkippedCheck struct {
+	SecurityCheck
+	Reason string // e.g. "incomplete file tree"
+}
+
+// SecurityReport is the outcome of the security hygiene checks.
+type SecurityReport struct {
+	Findings []SecurityFinding // Most severe first
+	Passed   []SecurityCheck   // Checks without findings
+	Skipped  []SkippedCheck    // Checks not run, neither passed nor failed
+}
+
+// Count returns the number of findings with severity.
+func (r SecurityReport) Count(severity string) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary counts the findings by severity, e.g. "1 high, 2 medium, 0 low".
+// Skipped checks are mentioned with their reasons, e.g. "No findings, 3
+// checks skipped (incomplete file tree)".
+func (r SecurityReport) Summary() string {
+	summary := "No findings"
+	if len(r.Findings) > 0 {
+		summary = fmt.Sprintf("%d high, %d medium, %d low",
+			r.Count(SeverityHigh), r.Count(SeverityMedium), r.Count(SeverityLow))
+	}
+	if len(r.Skipped) > 0 {
+		summary += ", " + r.SkipSummary()
+	}
+	return summary
+}
+
+// SkipSummary counts the skipped checks with their reasons, e.g. "3 checks
+// skipped (incomplete file tree)", or returns "" when none was skipped.
+func (r SecurityReport) SkipSummary() string {
+	if len(r.Skipped) == 0 {
+		return ""
+	}
+	var reasons []string
+	seen := make(map[string]bool)
+	for _, check := range r.Skipped {
+		if !seen[check.Reason] {
+			seen[check.Reason] = true
+			reasons = append(reasons, check.Reason)
+		}
+	}
+	return fmt.Sprintf("%d checks skipped (%s)", len(r.Skipped), strings.Join(reasons, "; "))
+}
+
+// lockfiles maps dependency manifests to the lockfiles that pin them.
+var lockfiles = map[string][]string{
+	"package.json":   {"package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb", "bun.lock"},
+	"go.mod":         {"go.sum"},
+	"Cargo.toml":     {"Cargo.lock"},
+	"Gemfile":        {"Gemfile.lock"},
+	"Pipfile":        {"Pipfile.lock"},
+	"pyproject.toml": {"poetry.lock", "uv.lock", "pdm.lock", "Pipfile.lock", "requirements.txt"},
+	"composer.json":  {"composer.lock"},
+}
+
+// dependencyUpdateConfigs are the files that configure Dependabot or
+// Renovate.
+var dependencyUpdateConfigs = []string{
+	".github/dependabot.yml", ".github/dependabot.yaml",
+	"renovate.json", "renovate.json5", ".renovaterc", ".renovaterc.json",
+	".github/renovate.json", ".github/renovate.json5", ".gitlab/renovate.json",
+}
+
+var (
+	secretNames      = map[string]bool{"id_rsa": true, "id_dsa": true, "id_ecdsa": true, "id_ed25519": true, ".env": true}
+	secretExtensions = map[string]bool{".pem": true, ".key": true, ".p12": true, ".pfx": true, ".jks": true, ".keystore": true}
+	envTemplates     = []string{".example", ".sample", ".template", ".dist"}
+	commitSHA        = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// missingFileChecks are the checks that flag files absent from the tree,
+// which a truncated tree can't tell.
+var missingFileChecks = map[string]bool{"security-policy": true, "missing-lockfile": true, "dependency-updates": true}
+
+// WorkflowPaths returns the GitHub Actions workflow files of a tree, the
+// files the workflow checks read, see github.FetchOptions.
+func WorkflowPaths(tree []github.TreeEntry) []string {
+	var paths []string
+	for _, entry := range tree {
+		if entry.Type != "blob" || !strings.HasPrefix(entry.Path, ".github/workflows/") {
+			continue
+		}
+		if strings.HasSuffix(entry.Path, ".yml") || strings.HasSuffix(entry.Path, ".yaml") {
+			paths = append(paths, entry.Path)
+		}
+	}
+	return paths
+}
+
+// UnlockedGoModules returns the go.mod files of a tree without a go.sum
+// beside them, the files the lockfile check reads, see
+// github.FetchOptions.
+func UnlockedGoModules(tree []github.TreeEntry) []string {
+	files := make(map[string]bool)
+	for _, entry := range tree {
+		if entry.Type == "blob" {
+			files[entry.Path] = true
+		}
+	}
+
+	var paths []string
+	for _, entry := range tree {
+		if entry.Type != "blob" || path.Base(entry.Path) != "go.mod" {
+			continue
+		}
+		if !files[path.Join(path.Dir(entry.Path), "go.sum")] {
+			paths = append(paths, entry.Path)
+		}
+	}
+	return paths
+}
+
+// AnalyzeSecurity checks a repository's file tree, GitHub Actions
+// workflows and go.mod files without a go.sum (both keyed by path) for
+// common security hygiene problems. The checks in skip, reasons keyed by
+// check ID, are skipped because their input is missing or incomplete.
+// When truncated is set, the tree lists only part of the repository and
+// the checks for missing files are skipped as well.
+func AnalyzeSecurity(tree []github.TreeEntry, truncated bool, workflows, goModules map[string][]byte, skip map[string]string) SecurityReport {
+	skipped := make(map[string]string, len(skip))
+	for id, reason := range skip {
+		skipped[id] = reason
+	}
+	if truncated {
+		for id := range missingFileChecks {
+			if _, ok := skipped[id]; !ok {
+				skipped[id] = "incomplete file tree"
+			}
+		}
+	}
+
+	files := make(map[string]bool)
+	for _, entry := range tree {
+		if entry.Type == "blob" {
+			files[entry.Path] = true
+		}
+	}
+
+	var findings []SecurityFinding
+	findings = append(findings, checkSecurityPolicy(files)...)
+	findings = append(findings, checkSecrets(files)...)
+	findings = append(findings, checkLockfiles(files, goModules)...)
+	findings = append(findings, checkDependencyUpdates(files)...)
+
+	workflowPaths := make([]string, 0, len(workflows))
+	for p := range workflows {
+		workflowPaths = append(workflowPaths, p)
+	}
+	sort.Strings(workflowPaths)
+	for _, p := range workflowPaths {
+		findings = append(findings, checkWorkflow(p, workflows[p])...)
+	}
+
+	// Findings of skipped checks rest on missing data
+	kept := findings[:0]
+	for _, f := range findings {
+		if _, ok := skipped[f.Check]; !ok {
+			kept = append(kept, f)
+		}
+	}
+	findings = kept
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank(findings[i].Severity) < severityRank(findings[j].Severity)
+	})
+
+	report := SecurityReport{Findings: findings}
+	for _, check := range SecurityChecks {
+		if reason, ok := skipped[check.ID]; ok {
+			report.Skipped = append(report.Skipped, SkippedCheck{SecurityCheck: check, Reason: reason})
+			continue
+		}
+		passed := true
+		for _, f := range findings {
+			if f.Check == check.ID {
+				passed = false
+				break
+			}
+		}
+		if passed {
+			report.Passed = append(report.Passed, check)
+		}
+	}
+	return report
+}
+
+func checkSecurityPolicy(files map[string]bool) []SecurityFinding {
+	for p := range files {
+		dir, base := path.Split(p)
+		if strings.EqualFold(base, "SECURITY.md") && (dir == "" || dir == ".github/" || dir == "docs/") {
+			return nil
+		}
+	}
+	return []SecurityFinding{{
+		Check:       "security-policy",
+		Severity:    SeverityMedium,
+		Title:       "No SECURITY.md security policy",
+		Remediation: "Add a SECURITY.md at the root, in .github/ or in docs/ that explains how to report vulnerabilities privately.",
+	}}
+}
+
+func checkSecrets(files map[string]bool) []SecurityFinding {
+	var findings []SecurityFinding
+	for p := range files {
+		if isSecretFile(p) {
+			findings = append(findings, SecurityFinding{
+				Check:       "committed-secret",
+				Severity:    SeverityHigh,
+				Title:       "Secret-looking file committed",
+				Path:        p,
+				Remediation: "Remove the file from the repository and its history, rotate any credentials it held, and add it to .gitignore.",
+			})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Path < findings[j].Path })
+	return findings
+}
+
+// isSecretFile reports whether p is named like a private key or an
+// environment file. Example environment files such as .env.example are
+// not secrets.
+func isSecretFile(p string) bool {
+	base := path.Base(p)
+	if secretNames[base] || secretExtensions[strings.ToLower(path.Ext(base))] {
+		return true
+	}
+	if strings.HasPrefix(base, ".env.") {
+		for _, suffix := range envTemplates {
+			if strings.HasSuffix(base, suffix) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// checkLockfiles flags dependency manifests without a lockfile. A go.mod
+// in goModules that requires no modules needs no go.sum.
+func checkLockfiles(files map[string]bool, goModules map[string][]byte) []SecurityFinding {
+	var findings []SecurityFinding
+	for p := range files {
+		candidates, ok := lockfiles[path.Base(p)]
+		if !ok || inVendoredDir(p) {
+			continue
+		}
+		if content, ok := goModules[p]; ok && !requiresModules(content) {
+			continue
+		}
+		dir := path.Dir(p)
+		locked := false
+		for _, lock := range candidates {
+			if files[path.Join(dir, lock)] {
+				locked = true
+				break
+			}
+		}
+		if !locked {
+			findings = append(findings, SecurityFinding{
+				Check:       "missing-lockfile",
+				Severity:    SeverityMedium,
+				Title:       "Dependency manifest without a lockfile",
+				Path:        p,
+				Remediation: "Commit a lockfile (" + strings.Join(candidates, ", ") + ") so builds use reviewed dependency versions.",
+			})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Path < findings[j].Path })
+	return findings
+}
+
+// requiresModules reports whether a go.mod file has require directives.
+func requiresModules(goMod []byte) bool {
+	for _, line := range strings.Split(string(goMod), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && strings.HasPrefix(fields[0], "require") {
+			return true
+		}
+	}
+	return false
+}
+
+// inVendoredDir reports whether p lies in a vendored directory.
+func inVendoredDir(p string) bool {
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		if vendoredDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+func checkDependencyUpdates(files map[string]bool) []SecurityFinding {
+	for _, p := range dependencyUpdateConfigs {
+		if files[p] {
+			return nil
+		}
+	}
+	return []SecurityFinding{{
+		Check:       "dependency-updates",
+		Severity:    SeverityLow,
+		Title:       "No automated dependency updates",
+		Remediation: "Add .github/dependabot.yml or a Renovate config so vulnerable dependencies get update pull requests.",
+	}}
+}
+
+// workflow is the part of a GitHub Actions workflow the checks look at.
+type workflow struct {
+	Permissions interface{} `yaml:"permissions"`
+	Jobs        map[string]struct {
+		Permissions interface{} `yaml:"permissions"`
+		Uses        string      `yaml:"uses"` // Reusable workflow
+		Steps       []struct {
+			Uses string `yaml:"uses"`
+		} `yaml:"steps"`
+	} `yaml:"jobs"`
+}
+
+func checkWorkflow(p string, content []byte) []SecurityFinding {
+	var wf workflow
+	if err := yaml.Unmarshal(content, &wf); err != nil {
+		return nil
+	}
+
+	var findings []SecurityFinding
+	seen := make(map[string]bool)
+	unpinned := func(uses string) {
+		if uses == "" || seen[uses] || isPinned(uses) {
+			return
+		}
+		seen[uses] = true
+		remediation := "Pin the action to a full 40-character commit SHA (with the version in a comment) so a moved tag can't change the code that runs."
+		if strings.HasPrefix(uses, "docker://") {
+			remediation = "Pin the image to a digest (@sha256:...) so a moved tag can't change the code that runs."
+		}
+		findings = append(findings, SecurityFinding{
+			Check:       "unpinned-action",
+			Severity:    SeverityMedium,
+			Title:       "Action not pinned to a commit SHA: " + uses,
+			Path:        p,
+			Remediation: remediation,
+		})
+	}
+
+	jobNames := make([]string, 0, len(wf.Jobs))
+	for name := range wf.Jobs {
+		jobNames = append(jobNames, name)
+	}
+	sort.Strings(jobNames)
+
+	allJobsScoped := len(jobNames) > 0
+	for _, name := range jobNames {
+		job := wf.Jobs[name]
+		unpinned(job.Uses)
+		for _, step := range job.Steps {
+			unpinned(step.Uses)
+		}
+		if job.Permissions == nil {
+			allJobsScoped = false
+		}
+		if isWriteAll(job.Permissions) {
+			findings = append(findings, writeAllFinding(p, "job "+name))
+		}
+	}
+
+	switch {
+	case isWriteAll(wf.Permissions):
+		findings = append(findings, writeAllFinding(p, "workflow"))
+	case wf.Permissions == nil && !allJobsScoped:
+		findings = append(findings, SecurityFinding{
+			Check:       "workflow-permissions",
+			Severity:    SeverityLow,
+			Title:       "Workflow doesn't restrict GITHUB_TOKEN permissions",
+			Path:        p,
+			Remediation: "Add a top-level `permissions:` block (e.g. `contents: read`) so jobs don't inherit the repository's default, possibly write-all, token.",
+		})
+	}
+	return findings
+}
+
+func writeAllFinding(p, scope string) SecurityFinding {
+	return SecurityFinding{
+		Check:       "workflow-permissions",
+		Severity:    SeverityHigh,
+		Title:       "GITHUB_TOKEN has write-all permissions in " + scope,
+		Path:        p,
+		Remediation: "Replace `permissions: write-all` with the individual scopes the job needs, e.g. `contents: read`.",
+	}
+}
+
+// isPinned reports whether a `uses:` reference is a local action, a
+// digest-pinned container or an action pinned to a commit SHA.
+func isPinned(uses string) bool {
+	if strings.HasPrefix(uses, "./") {
+		return true
+	}
+	if strings.HasPrefix(uses, "docker://") {
+		return strings.Contains(uses, "@sha256:")
+	}
+	i := strings.LastIndex(uses, "@")
+	return i >= 0 && commitSHA.MatchString(uses[i+1:])
+}
+
+func isWriteAll(permissions interface{}) bool {
+	s, ok := permissions.(string)
+	return ok && s == "write-all"
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityHigh:
+		return 0
+	case SeverityMedium:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,58 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// percentile returns the p-th percentile (0-100) of ds using the
+// nearest-rank method, or 0 for an empty slice. ds is sorted in place.
+func percentile(ds []time.Duration, p float64) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+
+	rank := int(math.Ceil(p/100*float64(len(ds)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(ds) {
+		rank = len(ds) - 1
+	}
+	return ds[rank]
+}
+
+// median returns the median of ds, or 0 for an empty slice.
+func median(ds []time.Duration) time.Duration {
+	return percentile(ds, 50)
+}
+
+// FormatDuration renders a duration the way the reports show it:
+// minutes under an hour, hours under two days, days otherwise.
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%.1fh", d.Hours())
+	default:
+		return fmt.Sprintf("%.1fd", d.Hours()/24)
+	}
+}
+
+// FormatBytes renders a size with a binary unit, e.g. "1.5 MB".
+func FormatBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := unit, 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
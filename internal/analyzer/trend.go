@@ -0,0 +1,266 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// Trend directions, see ActivityTrend.Direction.
+const (
+	TrendGrowing   = "Growing"
+	TrendDeclining = "Declining"
+	TrendStable    = "Stable"
+	TrendUnknown   = "Unknown"
+)
+
+// Thresholds of the trend analysis.
+const (
+	minTrendWeeks    = 4    // Fewer weeks give no trend
+	trendMinChange   = 0.25 // Fitted change over the window, relative to the mean, that counts as a trend
+	trendMinFit      = 0.1  // R² below which a slope is treated as noise
+	minSegmentWeeks  = 4    // Shortest stretch on either side of a change point
+	changePointScore = 3.0  // Mean shift, in standard errors, that makes a change point
+	changePointShift = 0.5  // Mean shift relative to the busier side that makes a change point
+	maxChangePoints  = 3
+)
+
+// WeekCount is the number of commits in the week starting at Start.
+type WeekCount struct {
+	Start   time.Time
+	Commits int
+}
+
+// ChangePoint is a week where weekly commits shifted abruptly, such as the
+// week a main maintainer stopped contributing.
+type ChangePoint struct {
+	Week   time.Time
+	Before float64 // Mean weekly commits since the previous change point
+	After  float64 // Mean weekly commits from Week to the next change point
+}
+
+// Drop reports whether activity fell at the change point.
+func (c ChangePoint) Drop() bool {
+	return c.After < c.Before
+}
+
+// String describes the change point, e.g. "2024-03-04: 12.0 → 1.5 commits/week".
+func (c ChangePoint) String() string {
+	return fmt.Sprintf("%s: %.1f → %.1f commits/week", c.Week.Format("2006-01-02"), c.Before, c.After)
+}
+
+// ActivityTrend is a linear trend fitted over weekly commit counts.
+type ActivityTrend struct {
+	Direction    string  // TrendGrowing, TrendDeclining, TrendStable or TrendUnknown
+	Slope        float64 // Fitted change in commits per week, per week
+	Change       float64 // Fitted change over the window relative to the mean, e.g. -0.4
+	Confidence   float64 // 0-1: the fit (R²) for a trend, the lack of one for Stable
+	Mean         float64 // Mean weekly commits
+	Weeks        []WeekCount
+	ChangePoints []ChangePoint
+}
+
+// String describes the trend, e.g. "Declining (72% confidence)".
+func (t ActivityTrend) String() string {
+	if t.Direction == TrendUnknown {
+		return t.Direction
+	}
+	return fmt.Sprintf("%s (%.0f%% confidence)", t.Direction, t.Confidence*100)
+}
+
+// AnalyzeActivityTrend buckets commits of the last days into weeks
+// (starting Monday, UTC), fits a least-squares line through the weekly
+// counts and classifies the repository as growing, declining or stable.
+// It also looks for abrupt shifts in the weekly mean with binary
+// segmentation. With fewer than four weeks of data the direction is
+// TrendUnknown.
+// Only whole weeks are counted: the week the window starts in and the
+// current week are left out. When truncated is set, commits holds only the
+// newest commits of the window, and the weeks before the oldest of them
+// are left out as well.
+func AnalyzeActivityTrend(commits []github.Commit, days int, truncated bool) ActivityTrend {
+	trend := ActivityTrend{Direction: TrendUnknown, Weeks: weeklyCommits(commits, days, truncated)}
+	if len(trend.Weeks) < minTrendWeeks {
+		return trend
+	}
+
+	counts := make([]float64, len(trend.Weeks))
+	for i, w := range trend.Weeks {
+		counts[i] = float64(w.Commits)
+	}
+	trend.Mean = mean(counts)
+	if trend.Mean == 0 {
+		return trend
+	}
+
+	slope, r2 := linearFit(counts)
+	trend.Slope = slope
+	trend.Change = slope * float64(len(counts)-1) / trend.Mean
+
+	switch {
+	case r2 >= trendMinFit && trend.Change >= trendMinChange:
+		trend.Direction, trend.Confidence = TrendGrowing, r2
+	case r2 >= trendMinFit && trend.Change <= -trendMinChange:
+		trend.Direction, trend.Confidence = TrendDeclining, r2
+	default:
+		trend.Direction, trend.Confidence = TrendStable, 1-r2
+	}
+
+	// Means are taken between neighbouring change points
+	points := changePoints(counts, 0, maxChangePoints)
+	for j, i := range points {
+		from, to := 0, len(counts)
+		if j > 0 {
+			from = points[j-1]
+		}
+		if j < len(points)-1 {
+			to = points[j+1]
+		}
+		trend.ChangePoints = append(trend.ChangePoints, ChangePoint{
+			Week:   trend.Weeks[i].Start,
+			Before: mean(counts[from:i]),
+			After:  mean(counts[i:to]),
+		})
+	}
+	return trend
+}
+
+// weeklyCommits counts commits per whole week over the last days,
+// including weeks without commits. With truncated set, it starts after the
+// week of the oldest commit, which may have lost some of its commits.
+func weeklyCommits(commits []github.Commit, days int, truncated bool) []WeekCount {
+	if days <= 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	start := now.AddDate(0, 0, -days)
+	if truncated && len(commits) > 0 {
+		oldest := commits[0].Commit.Author.Date.UTC()
+		for _, c := range commits[1:] {
+			if date := c.Commit.Author.Date.UTC(); date.Before(oldest) {
+				oldest = date
+			}
+		}
+		// Just past the oldest commit, so its week counts as partial
+		if oldest.After(start) {
+			start = oldest.Add(time.Nanosecond)
+		}
+	}
+	first := weekStart(start)
+	if first.Before(start) {
+		first = first.AddDate(0, 0, 7)
+	}
+	n := int(weekStart(now).Sub(first).Hours() / (24 * 7))
+	if n <= 0 {
+		return nil
+	}
+
+	weeks := make([]WeekCount, n)
+	for i := range weeks {
+		weeks[i].Start = first.AddDate(0, 0, 7*i)
+	}
+	for _, c := range commits {
+		i := int(weekStart(c.Commit.Author.Date.UTC()).Sub(first).Hours() / (24 * 7))
+		if i >= 0 && i < n {
+			weeks[i].Commits++
+		}
+	}
+	return weeks
+}
+
+// weekStart returns midnight of the Monday of t's week.
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// linearFit fits y = a + b·x with x the index of each value and returns
+// the slope b and the coefficient of determination R².
+func linearFit(ys []float64) (slope, r2 float64) {
+	n := float64(len(ys))
+	mx, my := (n-1)/2, mean(ys)
+
+	var sxy, sxx, syy float64
+	for i, y := range ys {
+		dx, dy := float64(i)-mx, y-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return 0, 0
+	}
+	slope = sxy / sxx
+	if syy > 0 {
+		r2 = sxy * sxy / (sxx * syy)
+	}
+	return slope, r2
+}
+
+// changePoints finds up to limit indexes where the mean of ys shifts
+// abruptly, offset by offset. It splits ys where the shift is most significant and
+// recurses into both halves.
+func changePoints(ys []float64, offset, limit int) []int {
+	if limit <= 0 || len(ys) < 2*minSegmentWeeks {
+		return nil
+	}
+
+	best, bestScore := -1, 0.0
+	for k := minSegmentWeeks; k <= len(ys)-minSegmentWeeks; k++ {
+		if score := shiftScore(ys[:k], ys[k:]); score > bestScore {
+			best, bestScore = k, score
+		}
+	}
+	if best < 0 || bestScore < changePointScore {
+		return nil
+	}
+	before, after := mean(ys[:best]), mean(ys[best:])
+	if math.Abs(before-after) < changePointShift*math.Max(before, after) {
+		return nil
+	}
+	// A gradual trend also splits into two different means; only keep the
+	// split when the weeks right around it differ as much.
+	if shiftScore(ys[best-minSegmentWeeks:best], ys[best:best+minSegmentWeeks]) < changePointScore {
+		return nil
+	}
+
+	left := changePoints(ys[:best], offset, limit-1)
+	points := append(left, offset+best)
+	right := changePoints(ys[best:], offset+best, limit-len(points))
+	return append(points, right...)
+}
+
+// shiftScore is the difference of the means of a and b in standard
+// errors. Commit counts are roughly Poisson, so the pooled variance is
+// never taken below the overall mean, which keeps flat stretches from
+// producing infinite scores.
+func shiftScore(a, b []float64) float64 {
+	ma, mb := mean(a), mean(b)
+	na, nb := float64(len(a)), float64(len(b))
+
+	var ss float64
+	for _, y := range a {
+		ss += (y - ma) * (y - ma)
+	}
+	for _, y := range b {
+		ss += (y - mb) * (y - mb)
+	}
+	variance := ss / (na + nb - 2)
+	variance = math.Max(variance, math.Max((ma*na+mb*nb)/(na+nb), 1))
+
+	return math.Abs(ma-mb) / math.Sqrt(variance*(1/na+1/nb))
+}
+
+func mean(ys []float64) float64 {
+	if len(ys) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, y := range ys {
+		sum += y
+	}
+	return sum / float64(len(ys))
+}
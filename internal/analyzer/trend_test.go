@@ -0,0 +1,226 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+func TestLinearFit(t *testing.T) {
+	tests := []struct {
+		name      string
+		ys        []float64
+		wantSlope float64
+		wantR2    float64
+	}{
+		{"single week", []float64{5}, 0, 0},
+		{"flat", []float64{3, 3, 3, 3}, 0, 0},
+		{"exact line", []float64{1, 3, 5, 7, 9}, 2, 1},
+		{"falling line", []float64{8, 6, 4, 2}, -2, 1},
+		{"noisy", []float64{1, 3, 2, 4}, 0.8, 0.64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slope, r2 := linearFit(tt.ys)
+			if math.Abs(slope-tt.wantSlope) > 1e-9 || math.Abs(r2-tt.wantR2) > 1e-9 {
+				t.Errorf("linearFit(%v) = %v, %v, want %v, %v", tt.ys, slope, r2, tt.wantSlope, tt.wantR2)
+			}
+		})
+	}
+}
+
+func TestChangePoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		ys     []float64
+		offset int
+		limit  int
+		want   []int
+	}{
+		{"flat", weeks(10, 16), 0, 3, nil},
+		{"too short to split", concat(weeks(10, 4), weeks(0, 3)), 0, 3, nil},
+		{"drop", concat(weeks(10, 8), weeks(0, 8)), 0, 3, []int{8}},
+		{"rise", concat(weeks(1, 6), weeks(12, 6)), 0, 3, []int{6}},
+		{"offset", concat(weeks(10, 8), weeks(0, 8)), 5, 3, []int{13}},
+		{"drop and partial recovery", concat(weeks(20, 8), weeks(0, 8), weeks(8, 8)), 0, 3, []int{8, 16}},
+		{"limited", concat(weeks(20, 8), weeks(0, 8), weeks(8, 8)), 0, 1, []int{8}},
+		{"no limit left", concat(weeks(10, 8), weeks(0, 8)), 0, 0, nil},
+		{"small shift", concat(weeks(10, 8), weeks(8, 8)), 0, 3, nil},
+		{"gradual ramp", ramp(16), 0, 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := changePoints(tt.ys, tt.offset, tt.limit)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("changePoints = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeActivityTrend(t *testing.T) {
+	const days = 7 * 13
+
+	tests := []struct {
+		name            string
+		days            int
+		perWeek         func(i, n int) int
+		wantDirection   string
+		wantDrops       []bool
+		wantMinConf     float64
+		wantChangeBelow float64
+		wantChangeAbove float64
+	}{
+		{
+			name:          "no commits",
+			days:          days,
+			perWeek:       func(i, n int) int { return 0 },
+			wantDirection: TrendUnknown,
+		},
+		{
+			name:          "window too short",
+			days:          14,
+			perWeek:       func(i, n int) int { return 5 },
+			wantDirection: TrendUnknown,
+		},
+		{
+			name:            "steady",
+			days:            days,
+			perWeek:         func(i, n int) int { return 5 },
+			wantDirection:   TrendStable,
+			wantMinConf:     1,
+			wantChangeBelow: 0.01,
+			wantChangeAbove: -0.01,
+		},
+		{
+			name:            "growing",
+			days:            days,
+			perWeek:         func(i, n int) int { return i + 1 },
+			wantDirection:   TrendGrowing,
+			wantMinConf:     0.99,
+			wantChangeBelow: math.Inf(1),
+			wantChangeAbove: trendMinChange,
+		},
+		{
+			name:            "declining",
+			days:            days,
+			perWeek:         func(i, n int) int { return n - i },
+			wantDirection:   TrendDeclining,
+			wantMinConf:     0.99,
+			wantChangeBelow: -trendMinChange,
+			wantChangeAbove: math.Inf(-1),
+		},
+		{
+			name: "maintainer left",
+			days: days,
+			perWeek: func(i, n int) int {
+				if i < n/2 {
+					return 12
+				}
+				return 0
+			},
+			wantDirection:   TrendDeclining,
+			wantDrops:       []bool{true},
+			wantChangeBelow: -trendMinChange,
+			wantChangeAbove: math.Inf(-1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits := commitsPerWeek(tt.days, tt.perWeek)
+			trend := AnalyzeActivityTrend(commits, tt.days, false)
+
+			if trend.Direction != tt.wantDirection {
+				t.Fatalf("direction = %s, want %s (change %.2f)", trend.Direction, tt.wantDirection, trend.Change)
+			}
+			if tt.wantDirection == TrendUnknown {
+				return
+			}
+			if trend.Confidence < tt.wantMinConf {
+				t.Errorf("confidence = %.2f, want at least %.2f", trend.Confidence, tt.wantMinConf)
+			}
+			if trend.Change >= tt.wantChangeBelow || trend.Change <= tt.wantChangeAbove {
+				t.Errorf("change = %.2f, want between %.2f and %.2f", trend.Change, tt.wantChangeAbove, tt.wantChangeBelow)
+			}
+			if len(trend.ChangePoints) != len(tt.wantDrops) {
+				t.Fatalf("change points = %v, want %d", trend.ChangePoints, len(tt.wantDrops))
+			}
+			for i, p := range trend.ChangePoints {
+				if p.Drop() != tt.wantDrops[i] {
+					t.Errorf("change point %s: drop = %v, want %v", p, p.Drop(), tt.wantDrops[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyzeActivityTrendTruncated(t *testing.T) {
+	const days = 7 * 13
+	all := commitsPerWeek(days, func(i, n int) int { return 5 })
+
+	// Only the newest commits were fetched: the weeks before the oldest
+	// of them must not count as weeks without commits
+	recent := all[len(all)-5*3:]
+	if trend := AnalyzeActivityTrend(recent, days, true); trend.Direction != TrendUnknown || len(trend.Weeks) >= 3 {
+		t.Errorf("truncated to 3 weeks: direction %s over %d weeks, want %s over fewer than 3", trend.Direction, len(trend.Weeks), TrendUnknown)
+	}
+	if trend := AnalyzeActivityTrend(recent, days, false); trend.Direction != TrendGrowing {
+		t.Errorf("complete list with 3 busy weeks: direction %s, want %s", trend.Direction, TrendGrowing)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 7; day++ {
+		at := monday.AddDate(0, 0, day).Add(13 * time.Hour)
+		if got := weekStart(at); !got.Equal(monday) {
+			t.Errorf("weekStart(%s) = %s, want %s", at.Format(time.RFC3339), got, monday)
+		}
+	}
+}
+
+// commitsPerWeek returns commits for the whole weeks AnalyzeActivityTrend
+// counts over days, perWeek(i, n) of them in the i-th of n weeks, oldest
+// first.
+func commitsPerWeek(days int, perWeek func(i, n int) int) []github.Commit {
+	var commits []github.Commit
+	all := weeklyCommits(nil, days, false)
+	for i, w := range all {
+		for j := 0; j < perWeek(i, len(all)); j++ {
+			var c github.Commit
+			c.Commit.Author.Date = w.Start.Add(36*time.Hour + time.Duration(j)*time.Minute)
+			commits = append(commits, c)
+		}
+	}
+	return commits
+}
+
+func weeks(commits float64, n int) []float64 {
+	ys := make([]float64, n)
+	for i := range ys {
+		ys[i] = commits
+	}
+	return ys
+}
+
+func ramp(n int) []float64 {
+	ys := make([]float64, n)
+	for i := range ys {
+		ys[i] = float64(i)
+	}
+	return ys
+}
+
+func concat(parts ...[]float64) []float64 {
+	var ys []float64
+	for _, p := range parts {
+		ys = append(ys, p...)
+	}
+	return ys
+}
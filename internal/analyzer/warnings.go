@@ -0,0 +1,112 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
+
+// metricSources are the fetches each metric is computed from, besides the
+// repository itself. A metric is left out of the health metrics and
+// PolicyMetrics when one of them failed, so that it reads as missing rather
+// than zero. The health score itself is always known: its rules on
+// missing metrics award no points.
+var metricSources = map[string][]string{
+	"commits":                {github.TaskCommits},
+	"contributors":           {github.TaskContributors},
+	"bus_factor":             {github.TaskContributors, github.TaskCommitFiles},
+	"open_issues":            {github.TaskIssues},
+	"issue_response_score":   {github.TaskIssues, github.TaskComments},
+	"issue_first_response_h": {github.TaskIssues, github.TaskComments},
+	"issue_close_h":          {github.TaskIssues},
+	"pr_merge_ratio":         {github.TaskPullRequests},
+	"pr_merge_h":             {github.TaskPullRequests},
+	"releases":               {github.TaskReleases},
+	"days_since_release":     {github.TaskReleases},
+	"activity_trend":         {github.TaskCommits},
+	"test_ratio":             {github.TaskTree},
+	"security_high":          {github.TaskTree, github.TaskWorkflows, github.TaskGoModules},
+	"security_findings":      {github.TaskTree, github.TaskWorkflows, github.TaskGoModules},
+	"maturity_score":         {github.TaskCommits, github.TaskContributors, github.TaskReleases},
+}
+
+// MetricWarning is a metric without data because a fetch it depends on
+// failed.
+type MetricWarning struct {
+	Metric string // Key of Analysis.PolicyMetrics
+	Task   string // The failed fetch, see github.RepoData.Errors
+	Err    error
+}
+
+// MetricWarnings lists the metrics that lack data because of failed
+// fetches, by metric.
+func (a Analysis) MetricWarnings() []MetricWarning {
+	var warnings []MetricWarning
+	for metric := range metricSources {
+		if task, err := a.fetchError(metric); err != nil {
+			warnings = append(warnings, MetricWarning{Metric: metric, Task: task, Err: err})
+		}
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Metric < warnings[j].Metric })
+	return warnings
+}
+
+// fetchError returns the first failed fetch metric depends on.
+func (a Analysis) fetchError(metric string) (string, error) {
+	return failedSource(metric, a.FetchErrors)
+}
+
+// failedSource returns the first fetch of metricSources[metric] in errs.
+func failedSource(metric string, errs map[string]error) (string, error) {
+	for _, task := range metricSources[metric] {
+		if err, ok := errs[task]; ok {
+			return task, err
+		}
+	}
+	return "", nil
+}
+
+// DataWarnings describes everything that limits the data of the analysis:
+// the failed fetches, then the truncated lists.
+func (a Analysis) DataWarnings() []string {
+	return append(a.FetchWarnings(), a.TruncationWarnings()...)
+}
+
+// FetchWarnings describes the failed fetches, by task, with the metrics
+// each left without data.
+func (a Analysis) FetchWarnings() []string {
+	metrics := make(map[string][]string)
+	for _, w := range a.MetricWarnings() {
+		metrics[w.Task] = append(metrics[w.Task], w.Metric)
+	}
+
+	tasks := make([]string, 0, len(a.FetchErrors))
+	for task := range a.FetchErrors {
+		tasks = append(tasks, task)
+	}
+	sort.Strings(tasks)
+
+	var warnings []string
+	for _, task := range tasks {
+		warning := a.FetchErrors[task].Error()
+		if len(metrics[task]) > 0 {
+			warning += "; no data for " + strings.Join(metrics[task], ", ")
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// TruncationWarnings describes the lists the max-items cap cut short, in
+// fetch order. Counts computed from them are lower bounds.
+func (a Analysis) TruncationWarnings() []string {
+	var warnings []string
+	for _, task := range []string{github.TaskCommits, github.TaskContributors, github.TaskPullRequests, github.TaskIssues, github.TaskComments} {
+		if limit, ok := a.Truncated[task]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s: only %d were fetched, so counts based on them are too low; raise the max-items cap for complete data", task, limit))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,256 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long a cached response is served without asking
+// GitHub. After that it is revalidated with a conditional request, and a
+// 304 Not Modified answer doesn't count against the rate limit.
+const DefaultCacheTTL = 10 * time.Minute
+
+// DefaultCacheMaxBytes caps the size of the cache directory. The oldest
+// entries are evicted first once it is exceeded.
+const DefaultCacheMaxBytes = 100 << 20
+
+// cachePruneInterval is how many writes pass between two size checks,
+// which list the whole cache directory. The first write of a process
+// always checks, so the cache exceeds its limit by at most that many
+// entries.
+const cachePruneInterval = 64
+
+// Cache is a persistent store of GitHub API responses keyed by URL and
+// token, kept together with their ETag and Last-Modified validators.
+type Cache struct {
+	dir      string
+	ttl      time.Duration
+	maxBytes int64
+	mu       sync.Mutex
+	writes   int // Entries stored by this process, see cachePruneInterval
+}
+
+// CacheStats describes the contents of a cache directory.
+type CacheStats struct {
+	Dir      string
+	Entries  int
+	Bytes    int64
+	MaxBytes int64
+	TTL      time.Duration
+	Oldest   time.Time
+	Newest   time.Time
+}
+
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Link         string    `json:"link,omitempty"`
+	Body         []byte    `json:"body"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// WithCache makes the client read and write responses through cache.
+// Passing nil disables caching.
+func WithCache(cache *Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// NewCache returns a cache stored in dir. The directory is created on the
+// first write.
+func NewCache(dir string, ttl time.Duration, maxBytes int64) *Cache {
+	return &Cache{dir: dir, ttl: ttl, maxBytes: maxBytes}
+}
+
+// DefaultCache returns the cache NewClient uses: the repo-lyzer directory
+// under the user cache dir, configured by these environment variables:
+//   - REPOLYZER_CACHE: set to "off" to disable caching
+//   - REPOLYZER_CACHE_DIR: cache location
+//   - REPOLYZER_CACHE_TTL: freshness window, e.g. "30m" or "0" to always revalidate
+//   - REPOLYZER_CACHE_MAX_MB: size limit in megabytes
+//
+// It returns nil when caching is disabled or no cache dir is available.
+func DefaultCache() *Cache {
+	if strings.EqualFold(os.Getenv("REPOLYZER_CACHE"), "off") {
+		return nil
+	}
+
+	dir := os.Getenv("REPOLYZER_CACHE_DIR")
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return nil
+		}
+		dir = filepath.Join(base, "repo-lyzer", "http")
+	}
+
+	ttl := DefaultCacheTTL
+	if d, err := time.ParseDuration(os.Getenv("REPOLYZER_CACHE_TTL")); err == nil {
+		ttl = d
+	}
+
+	maxBytes := int64(DefaultCacheMaxBytes)
+	if mb, err := strconv.Atoi(os.Getenv("REPOLYZER_CACHE_MAX_MB")); err == nil {
+		maxBytes = int64(mb) << 20
+	}
+
+	return NewCache(dir, ttl, maxBytes)
+}
+
+// Dir returns the directory the cache is stored in.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// key derives the file name of a response. The token is part of the key so
+// responses are never shared between identities.
+func (c *Cache) key(url, token string) string {
+	sum := sha256.Sum256([]byte(token + "\x00" + url))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// load returns the stored entry for key, if any.
+func (c *Cache) load(key string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// fresh reports whether entry may be served without revalidation.
+func (c *Cache) fresh(entry *cacheEntry) bool {
+	return time.Since(entry.StoredAt) < c.ttl
+}
+
+// store writes entry and, every cachePruneInterval writes, evicts the
+// oldest entries if the cache grew past its size limit. Failures are
+// ignored: the cache is only an optimization.
+func (c *Cache) store(key string, entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return
+	}
+
+	// Write to a temp file first so concurrent readers never see a partial entry
+	tmp := c.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, c.path(key)); err != nil {
+		os.Remove(tmp)
+		return
+	}
+
+	c.writes++
+	if c.writes%cachePruneInterval == 1 {
+		c.prune()
+	}
+}
+
+// prune removes the least recently written entries until the cache fits
+// in maxBytes. The caller must hold c.mu.
+func (c *Cache) prune() {
+	if c.maxBytes <= 0 {
+		return
+	}
+
+	files, total := c.files()
+	if total <= c.maxBytes {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, f := range files {
+		if total <= c.maxBytes {
+			break
+		}
+		if os.Remove(filepath.Join(c.dir, f.Name())) == nil {
+			total -= f.Size()
+		}
+	}
+}
+
+// files lists the cache entries on disk and their total size.
+func (c *Cache) files() ([]os.FileInfo, int64) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, 0
+	}
+
+	var files []os.FileInfo
+	var total int64
+	for _, e := range dirEntries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+		total += info.Size()
+	}
+	return files, total
+}
+
+// Clear deletes every cached response.
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err := os.RemoveAll(c.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Stats reports how many responses are cached and how much space they use.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := CacheStats{Dir: c.dir, MaxBytes: c.maxBytes, TTL: c.ttl}
+
+	files, total := c.files()
+	stats.Entries = len(files)
+	stats.Bytes = total
+	for _, f := range files {
+		if stats.Oldest.IsZero() || f.ModTime().Before(stats.Oldest) {
+			stats.Oldest = f.ModTime()
+		}
+		if f.ModTime().After(stats.Newest) {
+			stats.Newest = f.ModTime()
+		}
+	}
+	return stats
+}
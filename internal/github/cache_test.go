@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheKey(t *testing.T) {
+	c := NewCache(t.TempDir(), time.Hour, 0)
+	base := c.key("https://api.github.com/repos/o/r", "token")
+
+	tests := []struct {
+		name       string
+		url, token string
+		wantSame   bool
+	}{
+		{"same request", "https://api.github.com/repos/o/r", "token", true},
+		{"other token", "https://api.github.com/repos/o/r", "other", false},
+		{"no token", "https://api.github.com/repos/o/r", "", false},
+		{"other url", "https://api.github.com/repos/o/s", "token", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.key(tt.url, tt.token) == base; got != tt.wantSame {
+				t.Errorf("same key = %v, want %v", got, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestCachedGet(t *testing.T) {
+	tests := []struct {
+		name         string
+		ttl          time.Duration
+		etag         bool
+		changed      bool
+		wantRequests int
+		wantNotMod   int
+		wantLogin    string
+	}{
+		{"fresh entry served from disk", time.Hour, true, false, 1, 0, "v1"},
+		{"stale entry revalidated", 0, true, false, 2, 1, "v1"},
+		{"stale entry replaced", 0, true, true, 2, 0, "v2"},
+		{"stale entry without validators", 0, false, false, 2, 0, "v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version := "v1"
+			requests, notModified := 0, 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if tt.etag {
+					if r.Header.Get("If-None-Match") == `"`+version+`"` {
+						notModified++
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+					w.Header().Set("ETag", `"`+version+`"`)
+				}
+				fmt.Fprintf(w, `{"login":%q}`, version)
+			}))
+			defer srv.Close()
+
+			cache := NewCache(t.TempDir(), tt.ttl, 0)
+			c := newTestClient(t, srv, WithCache(cache))
+
+			var user User
+			if err := c.get(context.Background(), srv.URL+"/user", &user); err != nil {
+				t.Fatalf("first get: %v", err)
+			}
+			stored, ok := cache.load(cache.key(srv.URL+"/user", ""))
+			if !ok {
+				t.Fatal("response not cached")
+			}
+
+			if tt.changed {
+				version = "v2"
+			}
+			user = User{}
+			if err := c.get(context.Background(), srv.URL+"/user", &user); err != nil {
+				t.Fatalf("second get: %v", err)
+			}
+
+			if requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", requests, tt.wantRequests)
+			}
+			if notModified != tt.wantNotMod {
+				t.Errorf("answered %d requests with 304, want %d", notModified, tt.wantNotMod)
+			}
+			if user.Login != tt.wantLogin {
+				t.Errorf("login = %q, want %q", user.Login, tt.wantLogin)
+			}
+
+			// A revalidated entry is stored again so it counts as fresh
+			entry, _ := cache.load(cache.key(srv.URL+"/user", ""))
+			if tt.wantRequests > 1 && !entry.StoredAt.After(stored.StoredAt) {
+				t.Errorf("StoredAt = %v, not renewed from %v", entry.StoredAt, stored.StoredAt)
+			}
+		})
+	}
+}
+
+func TestCacheSeparatesTokens(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"login":%q}`, r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	cache := NewCache(t.TempDir(), time.Hour, 0)
+	for _, token := range []string{"a", "b", "a"} {
+		c := newTestClient(t, srv, WithCache(cache))
+		c.token = token
+
+		var user User
+		if err := c.get(context.Background(), srv.URL+"/user", &user); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if want := "Bearer " + token; user.Login != want {
+			t.Errorf("token %s got the response of %q", token, user.Login)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("made %d requests, want 2", requests)
+	}
+}
+
+func TestCachePrune(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxBytes func(entry int64) int64
+		wantKept []string
+	}{
+		{
+			name:     "no limit",
+			maxBytes: func(int64) int64 { return 0 },
+			wantKept: []string{"a", "b", "c"},
+		},
+		{
+			name:     "under the limit",
+			maxBytes: func(entry int64) int64 { return 3 * entry },
+			wantKept: []string{"a", "b", "c"},
+		},
+		{
+			name:     "oldest evicted first",
+			maxBytes: func(entry int64) int64 { return 2 * entry },
+			wantKept: []string{"b", "c"},
+		},
+		{
+			name:     "down to the newest",
+			maxBytes: func(entry int64) int64 { return entry + entry/2 },
+			wantKept: []string{"c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewCache(t.TempDir(), time.Hour, 0)
+
+			// Same-sized entries, a written first and c last
+			now := time.Now()
+			for i, key := range []string{"a", "b", "c"} {
+				cache.store(key, &cacheEntry{URL: key, Body: []byte("{}"), StoredAt: now})
+				mtime := now.Add(time.Duration(i-3) * time.Hour)
+				if err := os.Chtimes(cache.path(key), mtime, mtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+			info, err := os.Stat(cache.path("a"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cache.maxBytes = tt.maxBytes(info.Size())
+			cache.mu.Lock()
+			cache.prune()
+			cache.mu.Unlock()
+
+			var kept []string
+			for _, key := range []string{"a", "b", "c"} {
+				if _, err := os.Stat(cache.path(key)); err == nil {
+					kept = append(kept, key)
+				}
+			}
+			if fmt.Sprint(kept) != fmt.Sprint(tt.wantKept) {
+				t.Errorf("kept %v, want %v", kept, tt.wantKept)
+			}
+			if stats := cache.Stats(); stats.Entries != len(tt.wantKept) {
+				t.Errorf("Stats().Entries = %d, want %d", stats.Entries, len(tt.wantKept))
+			}
+		})
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "http")
+	cache := NewCache(dir, time.Hour, 0)
+
+	if err := cache.Clear(); err != nil {
+		t.Fatalf("Clear on a missing dir: %v", err)
+	}
+	cache.store("a", &cacheEntry{URL: "a", StoredAt: time.Now()})
+	if err := cache.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if _, ok := cache.load("a"); ok {
+		t.Error("entry survived Clear")
+	}
+}
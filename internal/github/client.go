@@ -1,15 +1,37 @@
 package github
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 )
 
+// DefaultMaxItems is the default cap on how many items a paginated list
+// call collects before it stops following the Link header.
+const DefaultMaxItems = 5000
+
+// DefaultTimeout is the default time limit for a single API request,
+// including reading the response body.
+const DefaultTimeout = 30 * time.Second
+
 type Client struct {
-	http  *http.Client
-	token string
+	http       *http.Client
+	token      string
+	maxItems   int
+	maxRetries int
+	maxWait    time.Duration
+	cache      *Cache
+	baseURL    string
+	graphqlURL string
+	configErr  error // Set by an option that failed, returned by every request
+
+	rateMu  sync.Mutex
+	budgets map[string]RateBudget
 }
 
 type User struct {
@@ -17,50 +39,225 @@ type User struct {
 	Name  string `json:"name"`
 }
 
-func NewClient() *Client {
-	return &Client{
-		http:  &http.Client{},
-		token: os.Getenv("GITHUB_TOKEN"),
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// WithMaxItems caps how many items paginated list calls collect.
+// A value of zero or less removes the cap.
+func WithMaxItems(n int) Option {
+	return func(c *Client) {
+		c.maxItems = n
+	}
+}
+
+// MaxItems returns the cap on how many items paginated list calls
+// collect, zero or less for none.
+func (c *Client) MaxItems() int {
+	return c.maxItems
+}
+
+// WithTimeout sets the time limit for a single API request. A value of
+// zero disables the limit; cancellation is then left to the caller's context.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.http.Timeout = d
+	}
+}
+
+// NewClient creates a client for github.com, or for the server named by
+// GITHUB_API_URL, authenticated with GITHUB_TOKEN when it is set.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		http:       &http.Client{Timeout: DefaultTimeout},
+		token:      os.Getenv("GITHUB_TOKEN"),
+		maxItems:   DefaultMaxItems,
+		maxRetries: DefaultMaxRetries,
+		maxWait:    DefaultMaxRateLimitWait,
+		cache:      DefaultCache(),
+		baseURL:    DefaultBaseURL,
+		graphqlURL: graphQLURLFor(DefaultBaseURL),
 	}
+	// Explicit options override the environment
+	opts = append(envOptions(), opts...)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // get performs a GET request to the GitHub API and decodes the JSON response.
 // It handles authentication using the client's token if available.
 // Parameters:
+//   - ctx: Context that cancels the request when done
 //   - url: The GitHub API endpoint URL
 //   - target: Pointer to struct where the JSON response will be decoded
 //
 // Returns an error if the request fails or the response cannot be decoded.
-func (c *Client) get(url string, target interface{}) error {
-	req, err := http.NewRequest("GET", url, nil)
+func (c *Client) get(ctx context.Context, url string, target interface{}) error {
+	_, err := c.getPage(ctx, url, target)
+	return err
+}
+
+// getPage works like get but also returns the response's Link header,
+// which points at the neighbouring pages of a list endpoint.
+// When the client has a cache, fresh entries are served without a request
+// and stale ones are revalidated with If-None-Match/If-Modified-Since.
+func (c *Client) getPage(ctx context.Context, url string, target interface{}) (string, error) {
+	var key string
+	var cached *cacheEntry
+	header := http.Header{}
+
+	if c.cache != nil {
+		key = c.cache.key(url, c.token)
+		if entry, ok := c.cache.load(key); ok {
+			if c.cache.fresh(entry) {
+				return entry.Link, decodeBody(url, entry.Body, target)
+			}
+			cached = entry
+			if entry.ETag != "" {
+				header.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	}
+
+	resp, err := c.do(ctx, "GET", url, nil, header)
 	if err != nil {
-		return err
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		cached.StoredAt = time.Now()
+		c.cache.store(key, cached)
+		return cached.Link, decodeBody(url, cached.Body, target)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", newAPIError(url, resp)
 	}
 
-	req.Header.Set("Accept", "application/vnd.github+json")
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if err := decodeBody(url, body, target); err != nil {
+		return "", err
+	}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	link := resp.Header.Get("Link")
+	if c.cache != nil {
+		c.cache.store(key, &cacheEntry{
+			URL:          url,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Link:         link,
+			Body:         body,
+			StoredAt:     time.Now(),
+		})
 	}
+	return link, nil
+}
 
-	resp, err := c.http.Do(req)
+// getUncached works like get but bypasses the cache, for responses that
+// must be current, such as the rate limit.
+func (c *Client) getUncached(ctx context.Context, url string, target interface{}) error {
+	resp, err := c.do(ctx, "GET", url, nil, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf(
-			"GitHub API error: %s (tip: set GITHUB_TOKEN env variable)",
-			resp.Status,
-		)
+		return newAPIError(url, resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return decodeBody(url, body, target)
+}
+
+// decodeBody unmarshals a JSON response body into target.
+func decodeBody(url string, body []byte, target interface{}) error {
+	if err := json.Unmarshal(body, target); err != nil {
+		return &DecodeError{
+			APIError: APIError{URL: url, StatusCode: http.StatusOK, Message: err.Error()},
+			Err:      err,
+		}
 	}
+	return nil
+}
+
+// do sends a request with the given body and extra headers and returns
+// the response. It records the rate limit budget of every response, waits for
+// an exhausted budget to reset before sending, and retries primary and
+// secondary rate limit rejections with jittered backoff.
+func (c *Client) do(ctx context.Context, method, url string, body []byte, header http.Header) (*http.Response, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	resource := resourceFor(url)
+
+	for attempt := 0; ; attempt++ {
+		if err := c.waitForBudget(ctx, url, resource); err != nil {
+			return nil, err
+		}
+
+		// The body is rebuilt on every attempt since a retry needs it unread
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reader)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
 
-	return json.NewDecoder(resp.Body).Decode(target)
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		c.recordRate(resource, resp.Header)
+
+		wait, limited := rateLimitDelay(resp, attempt)
+		if !limited {
+			return resp, nil
+		}
+
+		if attempt >= c.maxRetries || wait > c.maxWait {
+			defer resp.Body.Close()
+			return nil, &RateLimitedError{
+				APIError: APIError{URL: url, StatusCode: resp.StatusCode, Message: responseMessage(resp)},
+				Reset:    time.Now().Add(wait),
+			}
+		}
+		resp.Body.Close()
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
 }
 
 func (c *Client) GetUser() (*User, error) {
+	return c.GetUserContext(context.Background())
+}
+
+// GetUserContext is GetUser with a caller-supplied context.
+func (c *Client) GetUserContext(ctx context.Context) (*User, error) {
 	var u User
-	err := c.get("https://api.github.com/user", &u)
+	err := c.get(ctx, c.baseURL+"/user", &u)
 	return &u, err
 }
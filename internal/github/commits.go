@@ -1,22 +1,79 @@
 package github
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Commit struct {
 	SHA    string `json:"sha"`
 	Commit struct {
 		Author struct {
+			Name string    `json:"name"`
 			Date time.Time `json:"date"`
 		} `json:"author"`
 	} `json:"commit"`
 }
 
+// CommitDetail is a single commit with the files it changed.
+type CommitDetail struct {
+	Commit
+	Author *User        `json:"author"` // nil when no GitHub account matches the author
+	Files  []CommitFile `json:"files"`
+}
+
+// CommitFile is a file changed by a commit.
+type CommitFile struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Changes   int    `json:"changes"`
+}
 
+// AuthorLogin returns the GitHub login of the author, or the git author
+// name when the commit isn't linked to an account.
+func (d CommitDetail) AuthorLogin() string {
+	if d.Author != nil && d.Author.Login != "" {
+		return d.Author.Login
+	}
+	return d.Commit.Commit.Author.Name
+}
+
+// GetCommits fetches the commits of the last days days, following
+// pagination up to the client's max-items cap.
 func (c *Client) GetCommits(owner, repo string, days int) ([]Commit, error) {
-	var commits []Commit
-	since := time.Now().AddDate(0, 0, -days).Format(time.RFC3339)
+	return c.GetCommitsContext(context.Background(), owner, repo, days)
+}
 
-	url := "https://api.github.com/repos/" + owner + "/" + repo + "/commits?since=" + since
-	err := c.get(url, &commits)
+// GetCommitsContext is GetCommits with a caller-supplied context.
+func (c *Client) GetCommitsContext(ctx context.Context, owner, repo string, days int) ([]Commit, error) {
+	commits, _, err := c.listCommits(ctx, owner, repo, days)
 	return commits, err
 }
+
+// listCommits is GetCommitsContext that also reports whether the
+// max-items cap cut the commits short.
+func (c *Client) listCommits(ctx context.Context, owner, repo string, days int) ([]Commit, bool, error) {
+	url := c.baseURL + "/repos/" + owner + "/" + repo + "/commits?since=" + sinceDays(days)
+	return listAll[Commit](ctx, c, url)
+}
+
+// GetCommit fetches a single commit with the files it changed.
+func (c *Client) GetCommit(owner, repo, sha string) (*CommitDetail, error) {
+	return c.GetCommitContext(context.Background(), owner, repo, sha)
+}
+
+// GetCommitContext is GetCommit with a caller-supplied context.
+func (c *Client) GetCommitContext(ctx context.Context, owner, repo, sha string) (*CommitDetail, error) {
+	var d CommitDetail
+	err := c.get(ctx, c.baseURL+"/repos/"+owner+"/"+repo+"/commits/"+sha, &d)
+	return &d, err
+}
+
+// sinceDays formats the start of a window of the last days days for a
+// since parameter. The window starts at midnight UTC so the URL, and with
+// it the cache key, stays the same for repeated analyses on the same day.
+func sinceDays(days int) string {
+	return time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -days).Format(time.RFC3339)
+}
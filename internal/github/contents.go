@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FileContent is a file fetched through the contents API.
+type FileContent struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	SHA      string `json:"sha"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// Decode returns the file's bytes.
+func (f *FileContent) Decode() ([]byte, error) {
+	switch f.Encoding {
+	case "base64":
+		// The API wraps the encoded content at 60 characters
+		data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(f.Content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", f.Path, err)
+		}
+		return data, nil
+	case "", "none":
+		return []byte(f.Content), nil
+	}
+	return nil, fmt.Errorf("unsupported encoding %q of %s", f.Encoding, f.Path)
+}
+
+// GetFileContent fetches a file of a repository at ref, a branch, tag or
+// commit SHA. An empty ref means the default branch.
+func (c *Client) GetFileContent(owner, repo, path, ref string) (*FileContent, error) {
+	return c.GetFileContentContext(context.Background(), owner, repo, path, ref)
+}
+
+// GetFileContentContext is GetFileContent with a caller-supplied context.
+func (c *Client) GetFileContentContext(ctx context.Context, owner, repo, path, ref string) (*FileContent, error) {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	endpoint := c.baseURL + "/repos/" + owner + "/" + repo + "/contents/" + strings.Join(segments, "/")
+	if ref != "" {
+		endpoint += "?ref=" + url.QueryEscape(ref)
+	}
+	var f FileContent
+	if err := c.get(ctx, endpoint, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
@@ -1,6 +1,6 @@
 package github
 
-import "fmt"
+import "context"
 
 // Contributor represents a GitHub contributor
 type Contributor struct {
@@ -10,31 +10,18 @@ type Contributor struct {
 
 // GetContributors fetches ALL contributors (paginated)
 func (c *Client) GetContributors(owner, repo string) ([]Contributor, error) {
-	var allContributors []Contributor
-
-	page := 1
-	perPage := 100
-
-	for {
-		url := fmt.Sprintf(
-			"https://api.github.com/repos/%s/%s/contributors?per_page=%d&page=%d",
-			owner, repo, perPage, page,
-		)
-
-		var contributors []Contributor
-		err := c.get(url, &contributors)
-		if err != nil {
-			return nil, err
-		}
-
-		// Stop when no more contributors
-		if len(contributors) == 0 {
-			break
-		}
+	return c.GetContributorsContext(context.Background(), owner, repo)
+}
 
-		allContributors = append(allContributors, contributors...)
-		page++
-	}
+// GetContributorsContext is GetContributors with a caller-supplied context.
+func (c *Client) GetContributorsContext(ctx context.Context, owner, repo string) ([]Contributor, error) {
+	contributors, _, err := c.listContributors(ctx, owner, repo)
+	return contributors, err
+}
 
-	return allContributors, nil
+// listContributors is GetContributorsContext that also reports whether
+// the max-items cap cut the contributors short.
+func (c *Client) listContributors(ctx context.Context, owner, repo string) ([]Contributor, bool, error) {
+	url := c.baseURL + "/repos/" + owner + "/" + repo + "/contributors"
+	return listAll[Contributor](ctx, c, url)
 }
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the REST endpoint of github.com.
+const DefaultBaseURL = "https://api.github.com"
+
+// WithBaseURL points the client at another REST endpoint, such as
+// https://github.example.com/api/v3 for GitHub Enterprise Server. Unless
+// WithGraphQLURL is also given, the GraphQL endpoint is derived from it.
+func WithBaseURL(rawURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(rawURL, "/")
+		c.graphqlURL = graphQLURLFor(c.baseURL)
+	}
+}
+
+// WithGraphQLURL sets the GraphQL endpoint explicitly.
+func WithGraphQLURL(rawURL string) Option {
+	return func(c *Client) {
+		c.graphqlURL = strings.TrimSuffix(rawURL, "/")
+	}
+}
+
+// WithCABundle trusts the PEM certificates in path in addition to the
+// system roots, for servers signed by a private certificate authority.
+// A bundle that can't be loaded makes every request fail with that error.
+func WithCABundle(path string) Option {
+	return func(c *Client) {
+		pool, err := loadCABundle(path)
+		if err != nil {
+			c.configErr = err
+			return
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+		c.http.Transport = transport
+	}
+}
+
+// envOptions configures the client from the environment so the CLI and
+// the TUI reach the same server the same way:
+//   - GITHUB_API_URL: REST endpoint (also set by GitHub Actions runners)
+//   - GITHUB_GRAPHQL_URL: GraphQL endpoint
+//   - REPOLYZER_CA_BUNDLE: PEM file with extra trusted certificates
+//   - REPOLYZER_MAX_ITEMS: cap on the items of a paginated list
+//   - REPOLYZER_TIMEOUT: time limit for a single request, e.g. 2m
+func envOptions() []Option {
+	var opts []Option
+	if v := os.Getenv("GITHUB_API_URL"); v != "" {
+		opts = append(opts, WithBaseURL(v))
+	}
+	if v := os.Getenv("GITHUB_GRAPHQL_URL"); v != "" {
+		opts = append(opts, WithGraphQLURL(v))
+	}
+	if v := os.Getenv("REPOLYZER_CA_BUNDLE"); v != "" {
+		opts = append(opts, WithCABundle(v))
+	}
+	if v := os.Getenv("REPOLYZER_MAX_ITEMS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			opts = append(opts, withConfigError(fmt.Errorf("invalid REPOLYZER_MAX_ITEMS %q: %w", v, err)))
+		} else {
+			opts = append(opts, WithMaxItems(n))
+		}
+	}
+	if v := os.Getenv("REPOLYZER_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			opts = append(opts, withConfigError(fmt.Errorf("invalid REPOLYZER_TIMEOUT %q: %w", v, err)))
+		} else {
+			opts = append(opts, WithTimeout(d))
+		}
+	}
+	return opts
+}
+
+// withConfigError makes every request of the client fail with err.
+func withConfigError(err error) Option {
+	return func(c *Client) {
+		c.configErr = err
+	}
+}
+
+// graphQLURLFor derives the GraphQL endpoint from a REST endpoint:
+// api.github.com serves it at /graphql and GHES at /api/graphql.
+func graphQLURLFor(baseURL string) string {
+	if strings.HasSuffix(baseURL, "/api/v3") {
+		return strings.TrimSuffix(baseURL, "/v3") + "/graphql"
+	}
+	return baseURL + "/graphql"
+}
+
+// BaseURL returns the REST endpoint the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// GraphQLURL returns the GraphQL endpoint the client talks to.
+func (c *Client) GraphQLURL() string {
+	return c.graphqlURL
+}
+
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", path)
+	}
+	return pool, nil
+}
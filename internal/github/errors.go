@@ -0,0 +1,146 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// APIError is the part every GitHub API failure has in common: the
+// request URL, the HTTP status and the "message" of the response body.
+// The typed errors below embed it; use errors.As to tell them apart.
+type APIError struct {
+	URL        string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("GitHub API error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg + " (" + e.URL + ")"
+}
+
+// NotFoundError is returned for 404 responses, e.g. a misspelled
+// repository or a private one the token can't see.
+type NotFoundError struct{ APIError }
+
+// UnauthorizedError is returned for 401 responses: the token is invalid,
+// expired or revoked.
+type UnauthorizedError struct{ APIError }
+
+// ForbiddenError is returned for 403 responses that are not rate limits:
+// the token lacks the scope or SSO authorization for the resource.
+type ForbiddenError struct{ APIError }
+
+// RateLimitedError is returned when a primary or secondary rate limit
+// could not be waited out. Reset is when the budget becomes available.
+type RateLimitedError struct {
+	APIError
+	Reset time.Time
+}
+
+func (e *RateLimitedError) Error() string {
+	msg := "GitHub API rate limit exceeded, resets at " + e.Reset.Format("15:04:05")
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg + " (" + e.URL + ")"
+}
+
+// GoneError is returned for 410 responses, e.g. listing the issues of a
+// repository whose issue tracker is disabled.
+type GoneError struct{ APIError }
+
+// ServerError is returned for 5xx responses.
+type ServerError struct{ APIError }
+
+// DecodeError is returned when a successful response body isn't the JSON
+// the client expected.
+type DecodeError struct {
+	APIError
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode GitHub API response (%s): %v", e.URL, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError builds the typed error matching a failed response.
+func newAPIError(url string, resp *http.Response) error {
+	base := APIError{
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		Message:    responseMessage(resp),
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return &NotFoundError{base}
+	case resp.StatusCode == http.StatusUnauthorized:
+		return &UnauthorizedError{base}
+	case resp.StatusCode == http.StatusForbidden:
+		return &ForbiddenError{base}
+	case resp.StatusCode == http.StatusGone:
+		return &GoneError{base}
+	case resp.StatusCode >= 500:
+		return &ServerError{base}
+	default:
+		return &base
+	}
+}
+
+// responseMessage returns the "message" field GitHub puts in error bodies,
+// falling back to the raw body when it isn't JSON.
+func responseMessage(resp *http.Response) string {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		return payload.Message
+	}
+	return string(body)
+}
+
+// Hint returns targeted guidance for a GitHub API error, or "" when there
+// is nothing more useful to say than the error itself.
+func Hint(err error) string {
+	var (
+		notFound     *NotFoundError
+		unauthorized *UnauthorizedError
+		forbidden    *ForbiddenError
+		rateLimited  *RateLimitedError
+		serverErr    *ServerError
+		decodeErr    *DecodeError
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		return "Check the owner/repo spelling. Private repositories also need a GITHUB_TOKEN that can access them."
+	case errors.As(err, &unauthorized):
+		return "GitHub rejected the token. Check that GITHUB_TOKEN is valid and not expired, or unset it."
+	case errors.As(err, &forbidden):
+		return "The token lacks access to this resource. Check its scopes and, for organizations, SSO authorization."
+	case errors.As(err, &rateLimited):
+		return fmt.Sprintf("Wait until %s or set GITHUB_TOKEN for 5000 requests/hour.", rateLimited.Reset.Format("15:04"))
+	case errors.As(err, &serverErr):
+		return "GitHub is having trouble. Try again in a few minutes."
+	case errors.As(err, &decodeErr):
+		return "GitHub returned an unexpected response. Check your network, proxy or API URL."
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GraphQLError is one entry of the "errors" list of a GraphQL response.
+type GraphQLError struct {
+	Type    string        `json:"type"`
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+// GraphQLErrors is returned when a GraphQL query is rejected or answers
+// with errors and no data.
+type GraphQLErrors struct {
+	URL    string
+	Errors []GraphQLError
+}
+
+func (e *GraphQLErrors) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Message
+	}
+	return "GitHub GraphQL error: " + strings.Join(messages, "; ") + " (" + e.URL + ")"
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors"`
+}
+
+// graphql runs a GraphQL v4 query and decodes its "data" field into
+// target. GraphQL always requires a token. Errors GitHub reports inside a
+// 200 response are mapped to the same typed errors REST calls return, so
+// that a missing repository is still a *NotFoundError.
+func (c *Client) graphql(ctx context.Context, query string, variables map[string]interface{}, target interface{}) error {
+	url := c.graphqlURL
+	if c.token == "" {
+		return &UnauthorizedError{APIError{
+			URL:        url,
+			StatusCode: http.StatusUnauthorized,
+			Message:    "the GraphQL API requires a token",
+		}}
+	}
+
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	resp, err := c.do(ctx, "POST", url, body, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(url, resp)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var result graphQLResponse
+	if err := decodeBody(url, raw, &result); err != nil {
+		return err
+	}
+
+	if len(result.Errors) > 0 {
+		return c.graphQLError(url, result.Errors)
+	}
+	return decodeBody(url, result.Data, target)
+}
+
+// graphQLError converts the errors of a GraphQL response into the typed
+// error that best describes them.
+func (c *Client) graphQLError(url string, errs []GraphQLError) error {
+	first := errs[0]
+	base := APIError{URL: url, StatusCode: http.StatusOK, Message: first.Message}
+
+	switch first.Type {
+	case "NOT_FOUND":
+		base.StatusCode = http.StatusNotFound
+		return &NotFoundError{base}
+	case "FORBIDDEN":
+		base.StatusCode = http.StatusForbidden
+		return &ForbiddenError{base}
+	case "RATE_LIMITED":
+		reset := time.Now().Add(secondaryBackoff)
+		if budget, ok := c.Budget("graphql"); ok && budget.Reset.After(time.Now()) {
+			reset = budget.Reset
+		}
+		return &RateLimitedError{APIError: base, Reset: reset}
+	}
+	return &GraphQLErrors{URL: url, Errors: errs}
+}
@@ -1,12 +1,134 @@
 package github
 
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type Issue struct {
-	State string `json:"state"`
+	Number            int        `json:"number"`
+	Title             string     `json:"title"`
+	State             string     `json:"state"`
+	User              User       `json:"user"`
+	Labels            []Label    `json:"labels"`
+	Assignees         []User     `json:"assignees"`
+	Comments          int        `json:"comments"`
+	AuthorAssociation string     `json:"author_association"` // OWNER, MEMBER, CONTRIBUTOR, NONE, ...
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	ClosedAt          *time.Time `json:"closed_at"` // nil while the issue is open
+
+	// PullRequest is set when the "issue" is really a pull request; the
+	// issues endpoint returns both.
+	PullRequest *PullRequestRef `json:"pull_request,omitempty"`
+}
+
+// Label is an issue or pull request label.
+type Label struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
 }
 
-func (c *Client) GetIssues(owner, repo string, state string) ([]Issue, error) {
-	var issues []Issue
-	url := "https://api.github.com/repos/" + owner + "/" + repo + "/issues?state=" + state
-	err := c.get(url, &issues)
+// PullRequestRef marks an issue as a pull request.
+type PullRequestRef struct {
+	URL      string     `json:"url"`
+	MergedAt *time.Time `json:"merged_at"`
+}
+
+// IsPullRequest reports whether the issue is a pull request.
+func (i Issue) IsPullRequest() bool {
+	return i.PullRequest != nil
+}
+
+// IssueComment is a comment on an issue or pull request.
+type IssueComment struct {
+	ID                int       `json:"id"`
+	User              User      `json:"user"`
+	AuthorAssociation string    `json:"author_association"`
+	CreatedAt         time.Time `json:"created_at"`
+	IssueURL          string    `json:"issue_url"`
+}
+
+// IssueNumber returns the number of the issue the comment belongs to,
+// taken from its issue URL, or 0 when the URL has no number.
+func (ic IssueComment) IssueNumber() int {
+	n, _ := strconv.Atoi(ic.IssueURL[strings.LastIndex(ic.IssueURL, "/")+1:])
+	return n
+}
+
+// GetIssues fetches the issues in the given state ("open", "closed" or
+// "all") updated in the last days days, following pagination up to the
+// client's max-items cap. A days value of zero or less fetches them all.
+// Pull requests are left out; the cap counts them, though, since the API
+// returns both in the same pages. A repository with issues disabled has
+// none.
+func (c *Client) GetIssues(owner, repo, state string, days int) ([]Issue, error) {
+	return c.GetIssuesContext(context.Background(), owner, repo, state, days)
+}
+
+// GetIssuesContext is GetIssues with a caller-supplied context.
+func (c *Client) GetIssuesContext(ctx context.Context, owner, repo, state string, days int) ([]Issue, error) {
+	issues, _, err := c.listIssues(ctx, owner, repo, state, days)
 	return issues, err
 }
+
+// listIssues is GetIssuesContext that also reports whether the max-items
+// cap cut the issues short.
+func (c *Client) listIssues(ctx context.Context, owner, repo, state string, days int) ([]Issue, bool, error) {
+	url := c.baseURL + "/repos/" + owner + "/" + repo + "/issues?state=" + state
+	if days > 0 {
+		url += "&since=" + sinceDays(days)
+	}
+	all, truncated, err := listAll[Issue](ctx, c, url)
+	if issuesDisabled(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	issues := all[:0]
+	for _, issue := range all {
+		if !issue.IsPullRequest() {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, truncated, nil
+}
+
+// GetIssueComments fetches the comments on all issues and pull requests of
+// a repository updated in the last days days, newest first. A days value
+// of zero or less fetches them all, up to the client's max-items cap.
+func (c *Client) GetIssueComments(owner, repo string, days int) ([]IssueComment, error) {
+	return c.GetIssueCommentsContext(context.Background(), owner, repo, days)
+}
+
+// GetIssueCommentsContext is GetIssueComments with a caller-supplied context.
+func (c *Client) GetIssueCommentsContext(ctx context.Context, owner, repo string, days int) ([]IssueComment, error) {
+	comments, _, err := c.listIssueComments(ctx, owner, repo, days)
+	return comments, err
+}
+
+// listIssueComments is GetIssueCommentsContext that also reports whether
+// the max-items cap cut the comments short.
+func (c *Client) listIssueComments(ctx context.Context, owner, repo string, days int) ([]IssueComment, bool, error) {
+	url := c.baseURL + "/repos/" + owner + "/" + repo + "/issues/comments?sort=created&direction=desc"
+	if days > 0 {
+		url += "&since=" + sinceDays(days)
+	}
+	comments, truncated, err := listAll[IssueComment](ctx, c, url)
+	if issuesDisabled(err) {
+		return nil, false, nil
+	}
+	return comments, truncated, err
+}
+
+// issuesDisabled reports whether err is the 410 Gone the issue endpoints
+// answer for a repository whose issue tracker is disabled.
+func issuesDisabled(err error) bool {
+	var gone *GoneError
+	return errors.As(err, &gone)
+}
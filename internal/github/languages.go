@@ -1,7 +1,14 @@
 package github
 
+import "context"
+
 func (c *Client) GetLanguages(owner, repo string) (map[string]int, error) {
+	return c.GetLanguagesContext(context.Background(), owner, repo)
+}
+
+// GetLanguagesContext is GetLanguages with a caller-supplied context.
+func (c *Client) GetLanguagesContext(ctx context.Context, owner, repo string) (map[string]int, error) {
 	var langs map[string]int
-	err := c.get("https://api.github.com/repos/"+owner+"/"+repo+"/languages", &langs)
+	err := c.get(ctx, c.baseURL+"/repos/"+owner+"/"+repo+"/languages", &langs)
 	return langs, err
-}
\ No newline at end of file
+}
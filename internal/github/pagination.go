@@ -0,0 +1,158 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// perPage is the page size requested from list endpoints. 100 is the
+// maximum GitHub allows and keeps the number of round-trips low.
+const perPage = 100
+
+// pageIterator walks a paginated GitHub list endpoint by following the
+// rel="next" URL in each response's Link header.
+type pageIterator struct {
+	ctx    context.Context
+	client *Client
+	next   string
+	err    error
+}
+
+// paginate returns an iterator over the pages of a list endpoint.
+func (c *Client) paginate(ctx context.Context, endpoint string) *pageIterator {
+	return &pageIterator{ctx: ctx, client: c, next: withPerPage(endpoint)}
+}
+
+// Next decodes the next page into target. It returns false when there are
+// no more pages or a request failed; call Err to tell the two apart.
+func (it *pageIterator) Next(target interface{}) bool {
+	if it.next == "" || it.err != nil {
+		return false
+	}
+
+	link, err := it.client.getPage(it.ctx, it.next, target)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.next = nextPageURL(link)
+	return true
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *pageIterator) Err() error {
+	return it.err
+}
+
+// listAll collects the items of every page of a list endpoint, stopping
+// early once the client's max-items cap is reached. It reports whether
+// the cap cut the list short.
+func listAll[T any](ctx context.Context, c *Client, endpoint string) ([]T, bool, error) {
+	var all []T
+
+	it := c.paginate(ctx, endpoint)
+	for {
+		var page []T
+		if !it.Next(&page) {
+			break
+		}
+		all = append(all, page...)
+
+		if c.maxItems > 0 && len(all) >= c.maxItems {
+			return all[:c.maxItems], len(all) > c.maxItems || it.next != "", nil
+		}
+	}
+
+	return all, false, it.Err()
+}
+
+// withPerPage adds per_page to the endpoint unless the caller already set it.
+func withPerPage(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+
+	q := u.Query()
+	if q.Get("per_page") == "" {
+		q.Set("per_page", strconv.Itoa(perPage))
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+// nextPageURL extracts the rel="next" URL from a Link header such as
+//
+//	<https://api.github.com/...&page=2>; rel="next", <...&page=5>; rel="last"
+//
+// It returns "" when the header has no next link.
+func nextPageURL(link string) string {
+	return linkURL(link, "next")
+}
+
+// lastPageNumber returns the page number of the rel="last" URL of a Link
+// header, or 0 when the header has no last link.
+func lastPageNumber(link string) int {
+	u, err := url.Parse(linkURL(link, "last"))
+	if err != nil {
+		return 0
+	}
+	page, _ := strconv.Atoi(u.Query().Get("page"))
+	return page
+}
+
+// countItems returns the number of items a list endpoint has and decodes
+// the first one into first. It requests single-item pages so the page
+// number of the rel="last" link is the item count.
+func countItems(ctx context.Context, c *Client, endpoint string, first interface{}) (int, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return 0, err
+	}
+	q := u.Query()
+	q.Set("per_page", "1")
+	u.RawQuery = q.Encode()
+
+	var page []json.RawMessage
+	link, err := c.getPage(ctx, u.String(), &page)
+	if err != nil {
+		return 0, err
+	}
+	if len(page) == 0 {
+		return 0, nil
+	}
+	if first != nil {
+		if err := decodeBody(endpoint, page[0], first); err != nil {
+			return 0, err
+		}
+	}
+	if n := lastPageNumber(link); n > 0 {
+		return n, nil
+	}
+	return len(page), nil
+}
+
+// linkURL returns the URL of the given relation in a Link header.
+func linkURL(link, rel string) string {
+	for _, part := range strings.Split(link, ",") {
+		sections := strings.Split(part, ";")
+		if len(sections) < 2 {
+			continue
+		}
+
+		target := strings.TrimSpace(sections[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+
+		for _, param := range sections[1:] {
+			if strings.TrimSpace(param) == `rel="`+rel+`"` {
+				return strings.Trim(target, "<>")
+			}
+		}
+	}
+	return ""
+}
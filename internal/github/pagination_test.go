@@ -0,0 +1,225 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newTestClient returns a client for srv that ignores the environment
+// and has no cache unless opts add one.
+func newTestClient(t *testing.T, srv *httptest.Server, opts ...Option) *Client {
+	t.Helper()
+	for _, name := range []string{
+		"GITHUB_TOKEN", "GITHUB_API_URL", "GITHUB_GRAPHQL_URL", "REPOLYZER_CA_BUNDLE",
+		"REPOLYZER_MAX_ITEMS", "REPOLYZER_TIMEOUT",
+	} {
+		t.Setenv(name, "")
+	}
+	opts = append([]Option{WithBaseURL(srv.URL), WithCache(nil)}, opts...)
+	return NewClient(opts...)
+}
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{"empty", "", ""},
+		{
+			"next and last",
+			`<https://api.github.com/repos/o/r/issues?page=2>; rel="next", <https://api.github.com/repos/o/r/issues?page=5>; rel="last"`,
+			"https://api.github.com/repos/o/r/issues?page=2",
+		},
+		{
+			"next after prev",
+			`<https://api.github.com/x?page=1>; rel="prev", <https://api.github.com/x?page=3>; rel="next"`,
+			"https://api.github.com/x?page=3",
+		},
+		{"last page", `<https://api.github.com/x?page=1>; rel="first", <https://api.github.com/x?page=4>; rel="prev"`, ""},
+		{"no brackets", `https://api.github.com/x?page=2; rel="next"`, ""},
+		{"no rel", `<https://api.github.com/x?page=2>`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPageURL(tt.link); got != tt.want {
+				t.Errorf("nextPageURL(%q) = %q, want %q", tt.link, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastPageNumber(t *testing.T) {
+	tests := []struct {
+		link string
+		want int
+	}{
+		{"", 0},
+		{`<https://api.github.com/x?per_page=1&page=2>; rel="next", <https://api.github.com/x?per_page=1&page=42>; rel="last"`, 42},
+		{`<https://api.github.com/x?per_page=1&page=2>; rel="next"`, 0},
+		{`<https://api.github.com/x?page=abc>; rel="last"`, 0},
+	}
+
+	for _, tt := range tests {
+		if got := lastPageNumber(tt.link); got != tt.want {
+			t.Errorf("lastPageNumber(%q) = %d, want %d", tt.link, got, tt.want)
+		}
+	}
+}
+
+func TestWithPerPage(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"https://api.github.com/x", "https://api.github.com/x?per_page=100"},
+		{"https://api.github.com/x?state=all", "https://api.github.com/x?per_page=100&state=all"},
+		{"https://api.github.com/x?per_page=5", "https://api.github.com/x?per_page=5"},
+	}
+
+	for _, tt := range tests {
+		if got := withPerPage(tt.endpoint); got != tt.want {
+			t.Errorf("withPerPage(%q) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+// pagedServer serves total numbered items, pageSize per page, linking the
+// pages with rel="next" and rel="last" like GitHub does.
+func pagedServer(t *testing.T, total, pageSize int) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		lastPage := (total + pageSize - 1) / pageSize
+
+		if page < lastPage {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=%d>; rel="next", <%s/items?page=%d>; rel="last"`,
+				srv.URL, page+1, srv.URL, lastPage))
+		}
+
+		items := []int{}
+		for i := (page-1)*pageSize + 1; i <= page*pageSize && i <= total; i++ {
+			items = append(items, i)
+		}
+		json.NewEncoder(w).Encode(items)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name          string
+		total         int
+		maxItems      int
+		wantItems     int
+		wantTruncated bool
+		wantRequests  int
+	}{
+		{"all pages", 7, 0, 7, false, 3},
+		{"cap above total", 7, 10, 7, false, 3},
+		{"cap at total", 6, 6, 6, false, 2},
+		{"cap mid page", 7, 4, 4, true, 2},
+		{"cap at page end", 7, 3, 3, true, 1},
+		{"empty list", 0, 5, 0, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := pagedServer(t, tt.total, 3)
+			c := newTestClient(t, srv, WithMaxItems(tt.maxItems))
+
+			items, truncated, err := listAll[int](context.Background(), c, srv.URL+"/items")
+			if err != nil {
+				t.Fatalf("listAll: %v", err)
+			}
+			if len(items) != tt.wantItems {
+				t.Errorf("got %d items, want %d", len(items), tt.wantItems)
+			}
+			for i, item := range items {
+				if item != i+1 {
+					t.Errorf("item %d = %d, want %d", i, item, i+1)
+				}
+			}
+			if truncated != tt.wantTruncated {
+				t.Errorf("truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+			if *requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", *requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestListAllError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	if _, _, err := listAll[int](context.Background(), c, srv.URL+"/items"); err == nil {
+		t.Fatal("listAll succeeded on a 404")
+	}
+}
+
+func TestCountItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		want      int
+		wantFirst int
+	}{
+		{"empty", 0, 0, 0},
+		{"single item without Link", 1, 1, 1},
+		{"last page number", 42, 42, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := pagedServer(t, tt.total, 1)
+			c := newTestClient(t, srv)
+
+			var first int
+			got, err := countItems(context.Background(), c, srv.URL+"/items", &first)
+			if err != nil {
+				t.Fatalf("countItems: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("countItems = %d, want %d", got, tt.want)
+			}
+			if first != tt.wantFirst {
+				t.Errorf("first item = %d, want %d", first, tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestCountItemsRequestsSingleItemPages(t *testing.T) {
+	var perPage string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = r.URL.Query().Get("per_page")
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	if _, err := countItems(context.Background(), c, srv.URL+"/items?per_page=100", nil); err != nil {
+		t.Fatalf("countItems: %v", err)
+	}
+	if perPage != "1" {
+		t.Errorf("per_page = %q, want 1", perPage)
+	}
+}
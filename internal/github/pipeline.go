@@ -0,0 +1,381 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultWorkers is how many fetches a Pipeline runs at the same time.
+const DefaultWorkers = 6
+
+// Fetch task names, usable as keys of RepoData.Errors.
+const (
+	TaskRepo         = "repo"
+	TaskCommits      = "commits"
+	TaskContributors = "contributors"
+	TaskTree         = "file tree"
+	TaskReleases     = "releases"
+	TaskPullRequests = "pull requests"
+	TaskReviews      = "reviews"
+	TaskIssues       = "issues"
+	TaskComments     = "issue comments"
+	TaskCommitFiles  = "commit files"
+	TaskWorkflows    = "workflows"
+	TaskGoModules    = "go modules"
+)
+
+// reviewSample is how many of the newest pull requests get their reviews
+// fetched. Reviews take one request per pull request, so they are sampled.
+const reviewSample = 20
+
+// workflowLimit is how many of the files FetchOptions.Workflows selects
+// are fetched, one request each.
+const workflowLimit = 20
+
+// goModuleLimit is how many of the files FetchOptions.GoModules selects
+// are fetched, one request each.
+const goModuleLimit = 10
+
+// RepoData is the raw data the analyzers work on for one repository.
+type RepoData struct {
+	Owner         string
+	Name          string
+	Repo          *Repo
+	Snapshot      *RepoSnapshot
+	Commits       []Commit
+	Contributors  []Contributor
+	Languages     map[string]int
+	Tree          []TreeEntry
+	TreeTruncated bool // GitHub listed only part of a very large tree, see TreeResponse
+	Releases      []Release
+	Tags          []Tag // Only fetched when the repository has no published releases
+	PullRequests  []PullRequest
+	Reviews       map[int][]Review  // Keyed by pull request number, see reviewSample
+	Issues        []Issue           // All open issues and those closed in the window
+	Comments      []IssueComment    // Issue and pull request comments in the window
+	CommitFiles   []CommitDetail    // Newest commits with their files, see FetchOptions
+	Workflows     map[string][]byte // Files of FetchOptions.Workflows keyed by path, nil when not fetched
+	GoModules     map[string][]byte // Files of FetchOptions.GoModules keyed by path, nil when not fetched
+
+	// WorkflowsTruncated and GoModulesTruncated are set when the selectors
+	// picked more files than workflowLimit or goModuleLimit; only the first
+	// were fetched.
+	WorkflowsTruncated bool
+	GoModulesTruncated bool
+
+	// Errors holds the failed fetches keyed by task name. The fields of a
+	// failed task are left empty.
+	Errors map[string]error
+
+	// Truncated holds the tasks whose lists the client's max-items cap cut
+	// short, with the cap, see WithMaxItems. Their fields hold only the
+	// newest items.
+	Truncated map[string]int
+}
+
+// FetchOptions selects what a Pipeline fetches.
+type FetchOptions struct {
+	CommitDays int // Commit, pull request and issue history window in days
+
+	// CommitFiles is how many of the newest commits to fetch with their
+	// changed files, for the file ownership bus factor. It takes one
+	// request per commit; zero skips it.
+	CommitFiles int
+
+	// Workflows and GoModules select the files of the tree to fetch for
+	// the security checks, up to workflowLimit and goModuleLimit, one
+	// request each. A nil selector skips the fetch.
+	Workflows PathSelector
+	GoModules PathSelector
+}
+
+// PathSelector picks the paths of a file tree to fetch.
+type PathSelector func(tree []TreeEntry) []string
+
+// Pipeline fetches repository data concurrently. Every fetch runs on a
+// bounded pool of workers, and one Pipeline can be shared by several
+// repositories so that together they never exceed that bound.
+type Pipeline struct {
+	client *Client
+	slots  chan struct{}
+}
+
+// task is one fetch of the pipeline. It starts once all of its deps
+// finished successfully.
+type task struct {
+	name string
+	deps []string
+	run  func(ctx context.Context) error
+}
+
+// NewPipeline returns a pipeline that runs at most workers fetches at once.
+func NewPipeline(client *Client, workers int) *Pipeline {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Pipeline{client: client, slots: make(chan struct{}, workers)}
+}
+
+// Client returns the client the pipeline fetches with.
+func (p *Pipeline) Client() *Client {
+	return p.client
+}
+
+// Fetch gathers the data of owner/repo. The repository snapshot, which
+// includes the languages, is fetched first since everything else depends
+// on the repository existing; commits, contributors and the file tree then
+// load in parallel, along with the releases, or the tags of repositories
+// that don't publish releases, the pull requests with their reviews, and
+// the issues with their comments, which are skipped for repositories with
+// issues disabled.
+// Fetch only fails when the repository can't be fetched or ctx is done,
+// since every task of a canceled fetch fails; other failures are recorded
+// in RepoData.Errors.
+func (p *Pipeline) Fetch(ctx context.Context, owner, repo string, opts FetchOptions) (*RepoData, error) {
+	data := &RepoData{Owner: owner, Name: repo, Truncated: make(map[string]int)}
+	c := p.client
+
+	// Tasks run concurrently, so they record truncation under a lock
+	var truncMu sync.Mutex
+	truncate := func(name string, truncated bool) {
+		if truncated {
+			truncMu.Lock()
+			data.Truncated[name] = c.MaxItems()
+			truncMu.Unlock()
+		}
+	}
+
+	tasks := []task{
+		{name: TaskRepo, run: func(ctx context.Context) (err error) {
+			data.Snapshot, err = c.GetRepoSnapshotContext(ctx, owner, repo)
+			if err == nil {
+				data.Repo, data.Languages = &data.Snapshot.Repo, data.Snapshot.Languages
+			}
+			return err
+		}},
+		{name: TaskCommits, deps: []string{TaskRepo}, run: func(ctx context.Context) (err error) {
+			var truncated bool
+			data.Commits, truncated, err = c.listCommits(ctx, owner, repo, opts.CommitDays)
+			truncate(TaskCommits, truncated)
+			return err
+		}},
+		{name: TaskContributors, deps: []string{TaskRepo}, run: func(ctx context.Context) (err error) {
+			var truncated bool
+			data.Contributors, truncated, err = c.listContributors(ctx, owner, repo)
+			truncate(TaskContributors, truncated)
+			return err
+		}},
+		{name: TaskTree, deps: []string{TaskRepo}, run: func(ctx context.Context) (err error) {
+			data.Tree, data.TreeTruncated, err = c.fileTree(ctx, owner, repo, data.Repo.DefaultBranch)
+			return err
+		}},
+		{name: TaskReleases, deps: []string{TaskRepo}, run: func(ctx context.Context) (err error) {
+			if data.Snapshot.ReleaseCount > 0 {
+				if data.Releases, err = c.GetReleasesContext(ctx, owner, repo); err != nil {
+					return err
+				}
+			}
+			// Drafts and prereleases count in ReleaseCount but not as releases
+			for _, r := range data.Releases {
+				if r.Published() {
+					return nil
+				}
+			}
+			data.Tags, err = c.GetTagsContext(ctx, owner, repo)
+			return err
+		}},
+		{name: TaskPullRequests, deps: []string{TaskRepo}, run: func(ctx context.Context) (err error) {
+			var truncated bool
+			data.PullRequests, truncated, err = c.listPullRequests(ctx, owner, repo, "all", opts.CommitDays)
+			truncate(TaskPullRequests, truncated)
+			return err
+		}},
+		{name: TaskReviews, deps: []string{TaskPullRequests}, run: func(ctx context.Context) error {
+			data.Reviews = make(map[int][]Review)
+			for _, pr := range data.PullRequests {
+				if len(data.Reviews) == reviewSample {
+					break
+				}
+				if pr.Draft {
+					continue
+				}
+				reviews, err := c.GetPullRequestReviewsContext(ctx, owner, repo, pr.Number)
+				if err != nil {
+					return err
+				}
+				data.Reviews[pr.Number] = reviews
+			}
+			return nil
+		}},
+		{name: TaskIssues, deps: []string{TaskRepo}, run: func(ctx context.Context) error {
+			if !data.Repo.HasIssues {
+				return nil
+			}
+			open, openTruncated, err := c.listIssues(ctx, owner, repo, "open", 0)
+			if err != nil {
+				return err
+			}
+			closed, closedTruncated, err := c.listIssues(ctx, owner, repo, "closed", opts.CommitDays)
+			data.Issues = append(open, closed...)
+			truncate(TaskIssues, openTruncated || closedTruncated)
+			return err
+		}},
+		{name: TaskComments, deps: []string{TaskRepo}, run: func(ctx context.Context) (err error) {
+			if !data.Repo.HasIssues {
+				return nil
+			}
+			var truncated bool
+			data.Comments, truncated, err = c.listIssueComments(ctx, owner, repo, opts.CommitDays)
+			truncate(TaskComments, truncated)
+			return err
+		}},
+	}
+
+	if opts.CommitFiles > 0 {
+		tasks = append(tasks, task{name: TaskCommitFiles, deps: []string{TaskCommits}, run: func(ctx context.Context) error {
+			for i, commit := range data.Commits {
+				if i == opts.CommitFiles {
+					break
+				}
+				detail, err := c.GetCommitContext(ctx, owner, repo, commit.SHA)
+				if err != nil {
+					return err
+				}
+				data.CommitFiles = append(data.CommitFiles, *detail)
+			}
+			return nil
+		}})
+	}
+	if opts.Workflows != nil {
+		tasks = append(tasks, task{name: TaskWorkflows, deps: []string{TaskTree}, run: func(ctx context.Context) (err error) {
+			data.Workflows, data.WorkflowsTruncated, err = c.selectedFiles(ctx, owner, repo, data.Repo.DefaultBranch, opts.Workflows(data.Tree), workflowLimit)
+			return err
+		}})
+	}
+	if opts.GoModules != nil {
+		tasks = append(tasks, task{name: TaskGoModules, deps: []string{TaskTree}, run: func(ctx context.Context) (err error) {
+			data.GoModules, data.GoModulesTruncated, err = c.selectedFiles(ctx, owner, repo, data.Repo.DefaultBranch, opts.GoModules(data.Tree), goModuleLimit)
+			return err
+		}})
+	}
+
+	data.Errors = p.run(ctx, tasks)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err, ok := data.Errors[TaskRepo]; ok {
+		return nil, err
+	}
+	return data, nil
+}
+
+// selectedFiles fetches the contents of the first limit paths, keyed by
+// path, and reports whether there were more.
+func (c *Client) selectedFiles(ctx context.Context, owner, repo, branch string, paths []string, limit int) (map[string][]byte, bool, error) {
+	files := make(map[string][]byte)
+	for _, path := range paths {
+		if len(files) == limit {
+			return files, true, nil
+		}
+		file, err := c.GetFileContentContext(ctx, owner, repo, path, branch)
+		if err != nil {
+			return nil, false, err
+		}
+		content, err := file.Decode()
+		if err != nil {
+			return nil, false, err
+		}
+		files[path] = content
+	}
+	return files, false, nil
+}
+
+// FetchAll fetches several "owner/repo" names at once on the shared
+// worker pool. Results and errors are returned in the order of names; a
+// done ctx fails every fetch with ctx.Err(), see Fetch.
+func (p *Pipeline) FetchAll(ctx context.Context, names []string, opts FetchOptions) ([]*RepoData, []error) {
+	results := make([]*RepoData, len(names))
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		parts := strings.Split(name, "/")
+		if len(parts) != 2 {
+			errs[i] = fmt.Errorf("%s: repository must be in owner/repo format", name)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, owner, repo string) {
+			defer wg.Done()
+			results[i], errs[i] = p.Fetch(ctx, owner, repo, opts)
+		}(i, parts[0], parts[1])
+	}
+	wg.Wait()
+
+	return results, errs
+}
+
+// run executes tasks on the worker pool, each as soon as its dependencies
+// are done, and returns the errors of the tasks that failed or were
+// skipped because a dependency failed.
+func (p *Pipeline) run(ctx context.Context, tasks []task) map[string]error {
+	done := make(map[string]chan struct{}, len(tasks))
+	for _, t := range tasks {
+		done[t.name] = make(chan struct{})
+	}
+
+	var mu sync.Mutex
+	errs := make(map[string]error)
+	failed := func(name string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return errs[name]
+	}
+
+	var wg sync.WaitGroup
+	for _, t := range tasks {
+		wg.Add(1)
+		go func(t task) {
+			defer wg.Done()
+			defer close(done[t.name])
+
+			err := p.runTask(ctx, t, done, failed)
+			if err != nil {
+				mu.Lock()
+				errs[t.name] = err
+				mu.Unlock()
+			}
+		}(t)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// runTask waits for the dependencies of t, then runs it in a worker slot.
+func (p *Pipeline) runTask(ctx context.Context, t task, done map[string]chan struct{}, failed func(string) error) error {
+	for _, dep := range t.deps {
+		<-done[dep]
+		if err := failed(dep); err != nil {
+			return fmt.Errorf("skipped %s: %w", t.name, err)
+		}
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-p.slots }()
+
+	if err := t.run(ctx); err != nil {
+		if t.name == TaskRepo {
+			return err
+		}
+		return fmt.Errorf("failed to get %s: %w", t.name, err)
+	}
+	return nil
+}
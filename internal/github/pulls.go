@@ -0,0 +1,96 @@
+package github
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// PullRequest is a pull request as returned by the pulls list endpoint.
+type PullRequest struct {
+	Number            int        `json:"number"`
+	Title             string     `json:"title"`
+	State             string     `json:"state"`
+	Draft             bool       `json:"draft"`
+	User              User       `json:"user"`
+	AuthorAssociation string     `json:"author_association"` // OWNER, MEMBER, CONTRIBUTOR, NONE, ...
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	ClosedAt          *time.Time `json:"closed_at"` // nil while the pull request is open
+	MergedAt          *time.Time `json:"merged_at"` // nil unless the pull request was merged
+}
+
+// Merged reports whether the pull request was merged.
+func (pr PullRequest) Merged() bool {
+	return pr.MergedAt != nil
+}
+
+// Review is a review submitted on a pull request.
+type Review struct {
+	ID                int       `json:"id"`
+	User              User      `json:"user"`
+	State             string    `json:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED, ...
+	AuthorAssociation string    `json:"author_association"`
+	SubmittedAt       time.Time `json:"submitted_at"`
+}
+
+// GetPullRequests fetches the pull requests in the given state ("open",
+// "closed" or "all") opened in the last days days, newest first. A days
+// value of zero or less fetches them all, up to the client's max-items cap.
+func (c *Client) GetPullRequests(owner, repo, state string, days int) ([]PullRequest, error) {
+	return c.GetPullRequestsContext(context.Background(), owner, repo, state, days)
+}
+
+// GetPullRequestsContext is GetPullRequests with a caller-supplied context.
+func (c *Client) GetPullRequestsContext(ctx context.Context, owner, repo, state string, days int) ([]PullRequest, error) {
+	prs, _, err := c.listPullRequests(ctx, owner, repo, state, days)
+	return prs, err
+}
+
+// listPullRequests is GetPullRequestsContext that also reports whether the
+// max-items cap cut the pull requests short.
+func (c *Client) listPullRequests(ctx context.Context, owner, repo, state string, days int) ([]PullRequest, bool, error) {
+	url := c.baseURL + "/repos/" + owner + "/" + repo + "/pulls?state=" + state + "&sort=created&direction=desc"
+	if days <= 0 {
+		return listAll[PullRequest](ctx, c, url)
+	}
+
+	// The endpoint has no since parameter, so stop at the first page that
+	// reaches past the window instead
+	since := time.Now().AddDate(0, 0, -days)
+	var prs []PullRequest
+
+	it := c.paginate(ctx, url)
+	for {
+		var page []PullRequest
+		if !it.Next(&page) {
+			break
+		}
+		for _, pr := range page {
+			if pr.CreatedAt.Before(since) {
+				return prs, false, nil
+			}
+			prs = append(prs, pr)
+		}
+
+		if c.maxItems > 0 && len(prs) >= c.maxItems {
+			return prs[:c.maxItems], len(prs) > c.maxItems || it.next != "", nil
+		}
+	}
+
+	return prs, false, it.Err()
+}
+
+// GetPullRequestReviews fetches the reviews of a pull request in the order
+// they were submitted.
+func (c *Client) GetPullRequestReviews(owner, repo string, number int) ([]Review, error) {
+	return c.GetPullRequestReviewsContext(context.Background(), owner, repo, number)
+}
+
+// GetPullRequestReviewsContext is GetPullRequestReviews with a
+// caller-supplied context.
+func (c *Client) GetPullRequestReviewsContext(ctx context.Context, owner, repo string, number int) ([]Review, error) {
+	url := c.baseURL + "/repos/" + owner + "/" + repo + "/pulls/" + strconv.Itoa(number) + "/reviews"
+	reviews, _, err := listAll[Review](ctx, c, url)
+	return reviews, err
+}
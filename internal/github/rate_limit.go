@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"time"
 )
 type RateLimit struct {
@@ -13,8 +14,14 @@ type RateLimit struct {
 	} `json:"resources"`
 }
 func (c *Client) GetRateLimit() (*RateLimit, error) {
+	return c.GetRateLimitContext(context.Background())
+}
+
+// GetRateLimitContext is GetRateLimit with a caller-supplied context.
+// The rate limit is always fetched from GitHub, never from the cache.
+func (c *Client) GetRateLimitContext(ctx context.Context) (*RateLimit, error) {
 	var rateLimit RateLimit
-	err := c.get("https://api.github.com/rate_limit", &rateLimit)
+	err := c.getUncached(ctx, c.baseURL+"/rate_limit", &rateLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -23,3 +30,14 @@ func (c *Client) GetRateLimit() (*RateLimit, error) {
 func (r *RateLimit) ResetTime() time.Time {
 	return time.Unix(int64(r.Resources.Core.Reset), 0)
 }
+
+// Budget returns the core resource as a RateBudget.
+func (r *RateLimit) Budget() RateBudget {
+	return RateBudget{
+		Resource:  "core",
+		Limit:     r.Resources.Core.Limit,
+		Remaining: r.Resources.Core.Remaining,
+		Reset:     r.ResetTime(),
+		UpdatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"time"
+)
+
+// Release is a published GitHub release of a repository.
+type Release struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	CreatedAt   time.Time `json:"created_at"`
+	PublishedAt time.Time `json:"published_at"`
+	HTMLURL     string    `json:"html_url"`
+}
+
+// Published reports whether the release is a published stable release,
+// neither a draft nor a prerelease.
+func (r Release) Published() bool {
+	return !r.Draft && !r.Prerelease && !r.PublishedAt.IsZero()
+}
+
+// Tag is a git tag. Many projects tag versions without publishing
+// GitHub releases for them.
+type Tag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// GetReleases fetches the releases of a repository, newest first
+// (paginated).
+func (c *Client) GetReleases(owner, repo string) ([]Release, error) {
+	return c.GetReleasesContext(context.Background(), owner, repo)
+}
+
+// GetReleasesContext is GetReleases with a caller-supplied context.
+func (c *Client) GetReleasesContext(ctx context.Context, owner, repo string) ([]Release, error) {
+	url := c.baseURL + "/repos/" + owner + "/" + repo + "/releases"
+	releases, _, err := listAll[Release](ctx, c, url)
+	return releases, err
+}
+
+// GetTags fetches the tags of a repository (paginated).
+func (c *Client) GetTags(owner, repo string) ([]Tag, error) {
+	return c.GetTagsContext(context.Background(), owner, repo)
+}
+
+// GetTagsContext is GetTags with a caller-supplied context.
+func (c *Client) GetTagsContext(ctx context.Context, owner, repo string) ([]Tag, error) {
+	url := c.baseURL + "/repos/" + owner + "/" + repo + "/tags"
+	tags, _, err := listAll[Tag](ctx, c, url)
+	return tags, err
+}
@@ -1,6 +1,9 @@
 ﻿package github
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Repo struct {
 Name          string    `json:"name"`
@@ -16,14 +19,29 @@ WatchersCount int       `json:"watchers_count"`
 Language      string    `json:"language"`
 Fork          bool      `json:"fork"`
 Archived      bool      `json:"archived"`
+HasIssues     bool      `json:"has_issues"` // False when the issue tracker is disabled
 Private       bool      `json:"private"`
 DefaultBranch string    `json:"default_branch"`
 HTMLURL       string    `json:"html_url"`
 CloneURL      string    `json:"clone_url"`
+License       *License  `json:"license"`
+Topics        []string  `json:"topics"`
+}
+
+// License is the license GitHub detected for a repository.
+type License struct {
+Key    string `json:"key"`
+Name   string `json:"name"`
+SPDXID string `json:"spdx_id"`
 }
 
 func (c *Client) GetRepo(owner, repo string) (*Repo, error) {
+return c.GetRepoContext(context.Background(), owner, repo)
+}
+
+// GetRepoContext is GetRepo with a caller-supplied context.
+func (c *Client) GetRepoContext(ctx context.Context, owner, repo string) (*Repo, error) {
 var r Repo
-err := c.get("https://api.github.com/repos/"+owner+"/"+repo, &r)
+err := c.get(ctx, c.baseURL+"/repos/"+owner+"/"+repo, &r)
 return &r, err
 }
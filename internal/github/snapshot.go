@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Snapshot sources, see RepoSnapshot.Source.
+const (
+	SourceGraphQL = "graphql"
+	SourceREST    = "rest"
+)
+
+// RepoSnapshot is the metadata of a repository that doesn't depend on its
+// history: the repository itself, its languages, releases and open work.
+type RepoSnapshot struct {
+	Repo           Repo
+	Languages      map[string]int
+	ReleaseCount   int
+	LatestRelease  *Release // Most recently created release, nil when there is none
+	OpenIssueCount int      // Open issues, without pull requests
+	OpenPRCount    int
+	Source         string // SourceGraphQL or SourceREST
+}
+
+// snapshotQuery fetches everything a RepoSnapshot holds in one request.
+const snapshotQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    name
+    nameWithOwner
+    description
+    url
+    stargazerCount
+    forkCount
+    createdAt
+    updatedAt
+    pushedAt
+    isFork
+    isArchived
+    isPrivate
+    hasIssuesEnabled
+    primaryLanguage { name }
+    defaultBranchRef { name }
+    licenseInfo { key name spdxId }
+    repositoryTopics(first: 100) { nodes { topic { name } } }
+    languages(first: 100, orderBy: {field: SIZE, direction: DESC}) {
+      edges { size node { name } }
+    }
+    releases(first: 1, orderBy: {field: CREATED_AT, direction: DESC}) {
+      totalCount
+      nodes { tagName name isDraft isPrerelease createdAt publishedAt url }
+    }
+    issues(states: OPEN) { totalCount }
+    pullRequests(states: OPEN) { totalCount }
+  }
+}`
+
+type snapshotResponse struct {
+	Repository *struct {
+		Name            string    `json:"name"`
+		NameWithOwner   string    `json:"nameWithOwner"`
+		Description     string    `json:"description"`
+		URL             string    `json:"url"`
+		StargazerCount  int       `json:"stargazerCount"`
+		ForkCount       int       `json:"forkCount"`
+		CreatedAt       time.Time `json:"createdAt"`
+		UpdatedAt       time.Time `json:"updatedAt"`
+		PushedAt        time.Time `json:"pushedAt"`
+		IsFork          bool      `json:"isFork"`
+		IsArchived      bool      `json:"isArchived"`
+		IsPrivate       bool      `json:"isPrivate"`
+		HasIssues       bool      `json:"hasIssuesEnabled"`
+		PrimaryLanguage *struct {
+			Name string `json:"name"`
+		} `json:"primaryLanguage"`
+		DefaultBranchRef *struct {
+			Name string `json:"name"`
+		} `json:"defaultBranchRef"`
+		LicenseInfo *struct {
+			Key    string `json:"key"`
+			Name   string `json:"name"`
+			SPDXID string `json:"spdxId"`
+		} `json:"licenseInfo"`
+		RepositoryTopics struct {
+			Nodes []struct {
+				Topic struct {
+					Name string `json:"name"`
+				} `json:"topic"`
+			} `json:"nodes"`
+		} `json:"repositoryTopics"`
+		Languages struct {
+			Edges []struct {
+				Size int `json:"size"`
+				Node struct {
+					Name string `json:"name"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"languages"`
+		Releases struct {
+			TotalCount int `json:"totalCount"`
+			Nodes      []struct {
+				TagName      string    `json:"tagName"`
+				Name         string    `json:"name"`
+				IsDraft      bool      `json:"isDraft"`
+				IsPrerelease bool      `json:"isPrerelease"`
+				CreatedAt    time.Time `json:"createdAt"`
+				PublishedAt  time.Time `json:"publishedAt"`
+				URL          string    `json:"url"`
+			} `json:"nodes"`
+		} `json:"releases"`
+		Issues struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"issues"`
+		PullRequests struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"pullRequests"`
+	} `json:"repository"`
+}
+
+func (c *Client) GetRepoSnapshot(owner, repo string) (*RepoSnapshot, error) {
+	return c.GetRepoSnapshotContext(context.Background(), owner, repo)
+}
+
+// GetRepoSnapshotContext fetches the snapshot of owner/repo with a single
+// GraphQL query. Without a token, or when the GraphQL API fails for any
+// reason other than the repository not existing, it falls back to the
+// REST API, which takes four requests.
+func (c *Client) GetRepoSnapshotContext(ctx context.Context, owner, repo string) (*RepoSnapshot, error) {
+	if c.token == "" {
+		return c.restSnapshot(ctx, owner, repo)
+	}
+
+	snap, err := c.graphQLSnapshot(ctx, owner, repo)
+	var notFound *NotFoundError
+	if err == nil || errors.As(err, &notFound) || ctx.Err() != nil {
+		return snap, err
+	}
+	return c.restSnapshot(ctx, owner, repo)
+}
+
+// graphQLSnapshot fetches a snapshot with snapshotQuery.
+func (c *Client) graphQLSnapshot(ctx context.Context, owner, repo string) (*RepoSnapshot, error) {
+	var resp snapshotResponse
+	vars := map[string]interface{}{"owner": owner, "name": repo}
+	if err := c.graphql(ctx, snapshotQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+	r := resp.Repository
+	if r == nil {
+		return nil, &NotFoundError{APIError{URL: c.graphqlURL, StatusCode: http.StatusNotFound, Message: "Could not resolve to a Repository"}}
+	}
+
+	snap := &RepoSnapshot{
+		Repo: Repo{
+			Name:        r.Name,
+			FullName:    r.NameWithOwner,
+			Stars:       r.StargazerCount,
+			Forks:       r.ForkCount,
+			OpenIssues:  r.Issues.TotalCount + r.PullRequests.TotalCount, // Counted like REST's open_issues_count
+			Description: r.Description,
+			CreatedAt:   r.CreatedAt,
+			UpdatedAt:   r.UpdatedAt,
+			PushedAt:    r.PushedAt,
+			// REST's watchers_count is the star count, not the subscribers
+			WatchersCount: r.StargazerCount,
+			Fork:          r.IsFork,
+			Archived:      r.IsArchived,
+			Private:       r.IsPrivate,
+			HasIssues:     r.HasIssues,
+			HTMLURL:       r.URL,
+			CloneURL:      r.URL + ".git",
+			Topics:        []string{},
+		},
+		Languages:      make(map[string]int, len(r.Languages.Edges)),
+		ReleaseCount:   r.Releases.TotalCount,
+		OpenIssueCount: r.Issues.TotalCount,
+		OpenPRCount:    r.PullRequests.TotalCount,
+		Source:         SourceGraphQL,
+	}
+
+	if r.PrimaryLanguage != nil {
+		snap.Repo.Language = r.PrimaryLanguage.Name
+	}
+	if r.DefaultBranchRef != nil {
+		snap.Repo.DefaultBranch = r.DefaultBranchRef.Name
+	}
+	if r.LicenseInfo != nil {
+		snap.Repo.License = &License{Key: r.LicenseInfo.Key, Name: r.LicenseInfo.Name, SPDXID: r.LicenseInfo.SPDXID}
+	}
+	for _, node := range r.RepositoryTopics.Nodes {
+		snap.Repo.Topics = append(snap.Repo.Topics, node.Topic.Name)
+	}
+	for _, edge := range r.Languages.Edges {
+		snap.Languages[edge.Node.Name] = edge.Size
+	}
+	if len(r.Releases.Nodes) > 0 {
+		rel := r.Releases.Nodes[0]
+		snap.LatestRelease = &Release{
+			TagName:     rel.TagName,
+			Name:        rel.Name,
+			Draft:       rel.IsDraft,
+			Prerelease:  rel.IsPrerelease,
+			CreatedAt:   rel.CreatedAt,
+			PublishedAt: rel.PublishedAt,
+			HTMLURL:     rel.URL,
+		}
+	}
+	return snap, nil
+}
+
+// restSnapshot assembles a snapshot from the REST API. Release and pull
+// request totals come from single-item pages, see countItems.
+func (c *Client) restSnapshot(ctx context.Context, owner, repo string) (*RepoSnapshot, error) {
+	r, err := c.GetRepoContext(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	snap := &RepoSnapshot{Repo: *r, Source: SourceREST}
+
+	if snap.Languages, err = c.GetLanguagesContext(ctx, owner, repo); err != nil {
+		return nil, err
+	}
+
+	var latest Release
+	endpoint := c.baseURL + "/repos/" + owner + "/" + repo
+	if snap.ReleaseCount, err = countItems(ctx, c, endpoint+"/releases", &latest); err != nil {
+		return nil, err
+	}
+	if snap.ReleaseCount > 0 {
+		snap.LatestRelease = &latest
+	}
+
+	if snap.OpenPRCount, err = countItems(ctx, c, endpoint+"/pulls?state=open", nil); err != nil {
+		return nil, err
+	}
+	// open_issues_count includes pull requests
+	snap.OpenIssueCount = max(r.OpenIssues-snap.OpenPRCount, 0)
+
+	return snap, nil
+}
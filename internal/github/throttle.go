@@ -0,0 +1,191 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxRetries is how many times a rate limited request is retried
+// before the client gives up.
+const DefaultMaxRetries = 3
+
+// DefaultMaxRateLimitWait is the longest the client sleeps for a rate
+// limit to reset. Longer waits fail fast instead of hanging the caller.
+const DefaultMaxRateLimitWait = 10 * time.Minute
+
+// secondaryBackoff is the base delay after a secondary rate limit that
+// came without a Retry-After header. GitHub asks for at least a minute.
+const secondaryBackoff = time.Minute
+
+// RateBudget is the request budget GitHub reported for a rate limit
+// resource ("core", "search", "graphql") on the most recent response.
+type RateBudget struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Used      int
+	Reset     time.Time
+	UpdatedAt time.Time
+}
+
+// Known reports whether the budget was read from a response yet.
+func (b RateBudget) Known() bool {
+	return !b.UpdatedAt.IsZero()
+}
+
+// WithMaxRetries sets how many times a rate limited request is retried.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// WithMaxRateLimitWait sets the longest the client sleeps for a rate
+// limit to reset before returning an error instead.
+func WithMaxRateLimitWait(d time.Duration) Option {
+	return func(c *Client) {
+		c.maxWait = d
+	}
+}
+
+// RateBudget returns the live core API budget, as reported by the most
+// recent response. The second result is false until a request was made.
+func (c *Client) RateBudget() (RateBudget, bool) {
+	return c.Budget("core")
+}
+
+// Budget returns the live budget of the given rate limit resource.
+func (c *Client) Budget(resource string) (RateBudget, bool) {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+
+	b, ok := c.budgets[resource]
+	return b, ok
+}
+
+// resourceFor guesses which rate limit resource a request is billed to,
+// so an exhausted budget can be waited out before the request is sent.
+func resourceFor(url string) string {
+	switch {
+	case strings.Contains(url, "/search/"):
+		return "search"
+	case strings.HasSuffix(url, "/graphql"):
+		return "graphql"
+	default:
+		return "core"
+	}
+}
+
+// recordRate stores the budget carried by the X-RateLimit-* headers.
+func (c *Client) recordRate(resource string, h http.Header) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+
+	if r := h.Get("X-RateLimit-Resource"); r != "" {
+		resource = r
+	}
+	remaining, _ := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	used, _ := strconv.Atoi(h.Get("X-RateLimit-Used"))
+	reset, _ := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+
+	if c.budgets == nil {
+		c.budgets = make(map[string]RateBudget)
+	}
+	c.budgets[resource] = RateBudget{
+		Resource:  resource,
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      used,
+		Reset:     time.Unix(reset, 0),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// waitForBudget blocks until the budget of resource resets when it is
+// known to be exhausted, so the request is queued instead of rejected.
+func (c *Client) waitForBudget(ctx context.Context, url, resource string) error {
+	b, ok := c.Budget(resource)
+	if !ok || b.Remaining > 0 || !time.Now().Before(b.Reset) {
+		return nil
+	}
+
+	wait := withJitter(time.Until(b.Reset))
+	if wait > c.maxWait {
+		return &RateLimitedError{
+			APIError: APIError{URL: url, Message: "no " + resource + " requests left; request not sent"},
+			Reset:    b.Reset,
+		}
+	}
+	return sleepContext(ctx, wait)
+}
+
+// rateLimitDelay reports whether resp is a primary or secondary rate limit
+// rejection and, if so, how long to wait before retrying.
+func rateLimitDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return withJitter(time.Duration(secs) * time.Second), true
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err == nil {
+			return withJitter(time.Until(time.Unix(reset, 0))), true
+		}
+	}
+
+	// Secondary rate limits are 403s without budget headers; only the
+	// message tells them apart from a permission problem.
+	if resp.StatusCode == http.StatusTooManyRequests || mentionsRateLimit(resp) {
+		return withJitter(secondaryBackoff << attempt), true
+	}
+	return 0, false
+}
+
+// mentionsRateLimit peeks at the response body for GitHub's rate limit
+// message, leaving the body readable for the caller.
+func mentionsRateLimit(resp *http.Response) bool {
+	head, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+
+	return bytes.Contains(bytes.ToLower(head), []byte("rate limit"))
+}
+
+// withJitter adds up to 10% (at least one second) of random delay to d so
+// concurrent callers don't all retry at the same instant.
+func withJitter(d time.Duration) time.Duration {
+	if d < 0 {
+		d = 0
+	}
+	return d + time.Second + time.Duration(rand.Int63n(int64(d/10)+1))
+}
+
+// sleepContext sleeps for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,234 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitDelay(t *testing.T) {
+	reset := strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10)
+
+	tests := []struct {
+		name        string
+		status      int
+		header      map[string]string
+		body        string
+		attempt     int
+		wantLimited bool
+		min, max    time.Duration
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "not found", status: http.StatusNotFound, body: "rate limit"},
+		{
+			name: "retry after", status: http.StatusForbidden,
+			header:      map[string]string{"Retry-After": "20"},
+			wantLimited: true, min: 21 * time.Second, max: 23 * time.Second,
+		},
+		{
+			name: "retry after wins over reset", status: http.StatusTooManyRequests,
+			header:      map[string]string{"Retry-After": "5", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset},
+			wantLimited: true, min: 6 * time.Second, max: 7 * time.Second,
+		},
+		{
+			name: "primary limit", status: http.StatusForbidden,
+			header:      map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset},
+			wantLimited: true, min: 29 * time.Second, max: 35 * time.Second,
+		},
+		{
+			name: "secondary limit 429", status: http.StatusTooManyRequests,
+			wantLimited: true, min: secondaryBackoff + time.Second, max: secondaryBackoff*11/10 + time.Second,
+		},
+		{
+			name: "secondary limit backs off", status: http.StatusTooManyRequests, attempt: 2,
+			wantLimited: true, min: 4*secondaryBackoff + time.Second, max: 4*secondaryBackoff*11/10 + time.Second,
+		},
+		{
+			name: "secondary limit 403", status: http.StatusForbidden,
+			body:        `{"message":"You have exceeded a secondary rate limit."}`,
+			wantLimited: true, min: secondaryBackoff + time.Second, max: secondaryBackoff*11/10 + time.Second,
+		},
+		{
+			name: "remaining without reset", status: http.StatusForbidden,
+			header: map[string]string{"X-RateLimit-Remaining": "0"},
+		},
+		{name: "forbidden", status: http.StatusForbidden, body: `{"message":"Resource not accessible by integration"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+			for name, value := range tt.header {
+				resp.Header.Set(name, value)
+			}
+
+			wait, limited := rateLimitDelay(resp, tt.attempt)
+			if limited != tt.wantLimited {
+				t.Fatalf("limited = %v, want %v", limited, tt.wantLimited)
+			}
+			if wait < tt.min || wait > tt.max {
+				t.Errorf("wait = %v, want between %v and %v", wait, tt.min, tt.max)
+			}
+
+			// The body must stay readable for the error message
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.body {
+				t.Errorf("body = %q after the check, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestWaitForBudget(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		budget  *RateBudget
+		maxWait time.Duration
+		ctx     context.Context
+		wantErr error
+		limited bool
+	}{
+		{name: "unknown budget", ctx: canceled},
+		{
+			name:   "requests left",
+			budget: &RateBudget{Remaining: 10, Reset: time.Now().Add(time.Hour)},
+			ctx:    canceled,
+		},
+		{
+			name:   "reset passed",
+			budget: &RateBudget{Remaining: 0, Reset: time.Now().Add(-time.Minute)},
+			ctx:    canceled,
+		},
+		{
+			name:    "wait too long",
+			budget:  &RateBudget{Remaining: 0, Reset: time.Now().Add(time.Hour)},
+			maxWait: time.Minute,
+			ctx:     context.Background(),
+			limited: true,
+		},
+		{
+			name:    "canceled while waiting",
+			budget:  &RateBudget{Remaining: 0, Reset: time.Now().Add(30 * time.Second)},
+			maxWait: time.Minute,
+			ctx:     canceled,
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{maxWait: tt.maxWait}
+			if tt.budget != nil {
+				tt.budget.Resource = "core"
+				tt.budget.UpdatedAt = time.Now()
+				c.budgets = map[string]RateBudget{"core": *tt.budget}
+			}
+
+			err := c.waitForBudget(tt.ctx, "https://api.github.com/x", "core")
+
+			var rateErr *RateLimitedError
+			if got := errors.As(err, &rateErr); got != tt.limited {
+				t.Fatalf("err = %v, want RateLimitedError: %v", err, tt.limited)
+			}
+			if tt.limited {
+				if !rateErr.Reset.Equal(tt.budget.Reset) {
+					t.Errorf("Reset = %v, want %v", rateErr.Reset, tt.budget.Reset)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecordRate(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", "29")
+		w.Header().Set("X-RateLimit-Used", "1")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.Header().Set("X-RateLimit-Resource", "search")
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	if _, ok := c.RateBudget(); ok {
+		t.Fatal("budget known before any request")
+	}
+
+	var v struct{}
+	if err := c.get(context.Background(), srv.URL+"/search/code", &v); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	b, ok := c.Budget("search")
+	if !ok || !b.Known() {
+		t.Fatal("search budget not recorded")
+	}
+	if b.Limit != 30 || b.Remaining != 29 || b.Used != 1 || !b.Reset.Equal(reset) {
+		t.Errorf("budget = %+v", b)
+	}
+	if _, ok := c.RateBudget(); ok {
+		t.Error("search response recorded a core budget")
+	}
+}
+
+func TestRetryAfterRateLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxRetries   int
+		wantLimited  bool
+		wantRequests int
+	}{
+		{"retried", 1, false, 2},
+		{"out of retries", 0, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests == 1 {
+					w.Header().Set("Retry-After", "0")
+					http.Error(w, `{"message":"secondary rate limit"}`, http.StatusTooManyRequests)
+					return
+				}
+				w.Write([]byte(`{"login":"octocat"}`))
+			}))
+			defer srv.Close()
+			c := newTestClient(t, srv, WithMaxRetries(tt.maxRetries))
+
+			var user User
+			err := c.get(context.Background(), srv.URL+"/user", &user)
+
+			var rateErr *RateLimitedError
+			if got := errors.As(err, &rateErr); got != tt.wantLimited {
+				t.Fatalf("err = %v, want RateLimitedError: %v", err, tt.wantLimited)
+			}
+			if !tt.wantLimited && user.Login != "octocat" {
+				t.Errorf("login = %q, want octocat", user.Login)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package github
 
+import "context"
+
 type TreeEntry struct {
 	Path string `json:"path"`
 	Mode string `json:"mode"`
@@ -16,8 +18,20 @@ type TreeResponse struct {
 }
 
 func (c *Client) GetFileTree(owner, repo, branch string) ([]TreeEntry, error) {
+	return c.GetFileTreeContext(context.Background(), owner, repo, branch)
+}
+
+// GetFileTreeContext is GetFileTree with a caller-supplied context.
+func (c *Client) GetFileTreeContext(ctx context.Context, owner, repo, branch string) ([]TreeEntry, error) {
+	tree, _, err := c.fileTree(ctx, owner, repo, branch)
+	return tree, err
+}
+
+// fileTree is GetFileTreeContext that also reports whether GitHub cut the
+// recursive listing short, which it does for very large trees.
+func (c *Client) fileTree(ctx context.Context, owner, repo, branch string) ([]TreeEntry, bool, error) {
 	var t TreeResponse
 	// recursive=1 to get full tree
-	err := c.get("https://api.github.com/repos/"+owner+"/"+repo+"/git/trees/"+branch+"?recursive=1", &t)
-	return t.Tree, err
+	err := c.get(ctx, c.baseURL+"/repos/"+owner+"/"+repo+"/git/trees/"+branch+"?recursive=1", &t)
+	return t.Tree, t.Truncated, err
 }
@@ -0,0 +1,27 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// PrintBusFactor prints the bus factor and the contributors it counts.
+func PrintBusFactor(b analyzer.BusFactorResult) {
+	fmt.Println(SectionStyle.Render("\n🚌 Bus Factor"))
+
+	style := SuccessStyle
+	switch b.Risk {
+	case "High Risk", "Unknown":
+		style = ErrorStyle
+	case "Medium Risk":
+		style = WarningStyle
+	}
+
+	fmt.Println(style.Render(fmt.Sprintf("Bus Factor  : %d (%s)", b.Count, b.Risk)))
+	fmt.Printf("Based On    : %s, %.0f%% of the work\n", b.Method, b.Share*100)
+	if len(b.Members) > 0 {
+		fmt.Printf("Key People  : %s\n", strings.Join(b.Members, ", "))
+	}
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// PrintFiles prints the composition of the repository's file tree.
+func PrintFiles(s analyzer.FileStats) {
+	fmt.Println(SectionStyle.Render("\n🗂️ Files"))
+
+	if s.Files == 0 {
+		fmt.Println(WarningStyle.Render("No file tree available"))
+		return
+	}
+
+	fmt.Printf("Files       : %d in %d directories, %s\n", s.Files, s.Dirs, analyzer.FormatBytes(s.TotalBytes))
+	fmt.Printf("Max Depth   : %d\n", s.MaxDepth)
+	fmt.Printf("Tests       : %d test / %d source files (ratio %.2f)\n", s.TestFiles, s.SourceFiles, s.TestRatio)
+	if len(s.Vendored) > 0 {
+		fmt.Printf("Vendored    : %s\n", strings.Join(s.Vendored, ", "))
+	}
+	if len(s.Generated) > 0 {
+		fmt.Printf("Generated   : %s\n", strings.Join(s.Generated, ", "))
+	}
+
+	printGroups("Extension", s.ByExtension, 8)
+	printGroups("Directory", s.ByDirectory, 8)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Largest File", "Size"})
+	for _, f := range s.Largest {
+		table.Append([]string{f.Path, analyzer.FormatBytes(f.Bytes)})
+	}
+	table.Render()
+}
+
+// printGroups prints the first max groups as a table.
+func printGroups(title string, groups []analyzer.FileGroup, max int) {
+	if len(groups) > max {
+		groups = groups[:max]
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{title, "Files", "Size"})
+	for _, g := range groups {
+		table.Append([]string{g.Name, fmt.Sprint(g.Files), analyzer.FormatBytes(g.Bytes)})
+	}
+	table.Render()
+}
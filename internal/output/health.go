@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/agnivo988/Repo-lyzer/internal/github"
 )
 
@@ -28,11 +29,18 @@ func PrintHealth(score int) {
 		fmt.Sprintf("\n🏆 Repo Health Score : %d/100 (%s)\n",score,label),
 	 ))
 }
+// PrintGitHubAPIStatus prints the client's request budget. It uses the
+// live numbers from the last API response and only calls /rate_limit when
+// no request has been made yet.
 func PrintGitHubAPIStatus(client *github.Client) {
-	rateLimit, err := client.GetRateLimit()
-	if err != nil {
-		fmt.Println("⚠️ Unable to fetch GitHub API status")
-		return
+	budget, ok := client.RateBudget()
+	if !ok {
+		rateLimit, err := client.GetRateLimit()
+		if err != nil {
+			fmt.Println("⚠️ Unable to fetch GitHub API status")
+			return
+		}
+		budget = rateLimit.Budget()
 	}
 
 	mode := "Unauthenticated"
@@ -47,11 +55,26 @@ if os.Getenv("GITHUB_TOKEN") != "" {
 	fmt.Printf("Mode        : %s\n", mode)
 	fmt.Printf(
 		"Requests    : %d / %d\n",
-		rateLimit.Resources.Core.Remaining,
-		rateLimit.Resources.Core.Limit,
+		budget.Remaining,
+		budget.Limit,
 	)
 	fmt.Printf(
 		"Resets At   : %s\n\n",
-		rateLimit.ResetTime().Format("15:04"),
+		budget.Reset.Format("15:04"),
 	)
 }
+
+// PrintHealthBreakdown prints the points every health rule awarded and why.
+func PrintHealthBreakdown(r analyzer.HealthReport) {
+	fmt.Println(SectionStyle.Render("🧮 Score Breakdown"))
+	fmt.Printf("  %-16s %+6.1f  base score\n", "base", r.Base)
+
+	for _, rule := range r.Rules {
+		mark := "✗"
+		if rule.Passed {
+			mark = "✓"
+		}
+		fmt.Printf("%s %-16s %+6.1f  %s\n", mark, rule.Rule.Name, rule.Points, rule.Reason)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// The subset of the JUnit XML format CI systems read.
+type (
+	junitSuites struct {
+		XMLName  xml.Name     `xml:"testsuites"`
+		Name     string       `xml:"name,attr"`
+		Tests    int          `xml:"tests,attr"`
+		Failures int          `xml:"failures,attr"`
+		Skipped  int          `xml:"skipped,attr"`
+		Suites   []junitSuite `xml:"testsuite"`
+	}
+
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Skipped  int         `xml:"skipped,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	}
+
+	junitSkipped struct {
+		Message string `xml:"message,attr"`
+	}
+
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// WriteJUnit writes policy checks as a JUnit XML report: a test suite per
+// repository and a test case per rule, failed when the rule failed and
+// skipped when the rule was skipped for incomplete data.
+func WriteJUnit(w io.Writer, reports []analyzer.PolicyReport) error {
+	suites := junitSuites{Name: toolName}
+	for _, r := range reports {
+		suite := junitSuite{Name: r.Repo, Tests: len(r.Results), Failures: r.Failures() - r.Skips(), Skipped: r.Skips()}
+		for _, result := range r.Results {
+			c := junitCase{Name: result.Rule.Name, ClassName: r.Repo}
+			switch {
+			case result.Skipped:
+				c.Skipped = &junitSkipped{Message: result.Reason}
+			case !result.Passed:
+				c.Failure = &junitFailure{Message: result.Reason, Type: "PolicyViolation", Text: result.Rule.Description}
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
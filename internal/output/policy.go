@@ -0,0 +1,39 @@
+package output
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// PrintPolicy prints the pass/fail table of a policy check.
+func PrintPolicy(r analyzer.PolicyReport) {
+	fmt.Println(SectionStyle.Render("\n🚦 Policy Check: " + r.Repo))
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Rule", "Result", "Why"})
+	for _, result := range r.Results {
+		status := "PASS"
+		switch {
+		case result.Skipped:
+			status = "SKIP"
+		case !result.Passed:
+			status = "FAIL"
+		}
+		table.Append([]string{result.Rule.Name, status, result.Reason})
+	}
+	table.Render()
+
+	if r.Passed() {
+		fmt.Println(SuccessStyle.Render(fmt.Sprintf("✓ All %d rules passed", len(r.Results))))
+	} else {
+		summary := fmt.Sprintf("✗ %d of %d rules failed", r.Failures()-r.Skips(), len(r.Results))
+		if r.Skips() > 0 {
+			summary += fmt.Sprintf(", %d skipped for incomplete data", r.Skips())
+		}
+		fmt.Println(ErrorStyle.Render(summary))
+	}
+}
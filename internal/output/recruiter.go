@@ -16,9 +16,18 @@ func PrintRecruiterSummary(s analyzer.RecruiterSummary) {
 	fmt.Println("Repository:", s.RepoName)
 	fmt.Println("⭐ Stars:", s.Stars)
 	fmt.Println("🍴 Forks:", s.Forks)
-	fmt.Println("📦 Commits (1y):", s.CommitsLastYear)
+	fmt.Printf("📦 Commits (%dd): %d\n", s.Days, s.Commits)
 	fmt.Println("👥 Contributors:", s.Contributors)
 	fmt.Println("🏗️ Maturity:", s.MaturityLevel, "(", s.MaturityScore, ")")
 	fmt.Println("⚠️ Bus Factor:", s.BusFactor, "-", s.BusRisk)
 	fmt.Println("🔥 Activity:", s.ActivityLevel)
+	if s.Trend != "" {
+		fmt.Println("📈 Trend:", s.Trend)
+	}
+	if s.IssueHealth != "" {
+		fmt.Println("🐛 Issue Health:", s.IssueHealth)
+	}
+	if s.PRHealth != "" {
+		fmt.Println("🔀 PR Health:", s.PRHealth)
+	}
 }
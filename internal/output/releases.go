@@ -0,0 +1,31 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// PrintReleases prints how often and how recently the repository ships.
+func PrintReleases(s analyzer.ReleaseStats) {
+	fmt.Println(SectionStyle.Render("\n🚀 Releases"))
+
+	if !s.HasReleases() {
+		fmt.Println(WarningStyle.Render("No releases or tags published"))
+		return
+	}
+
+	if s.FromTags {
+		fmt.Printf("Tags        : %d (no GitHub releases)\n", s.Count)
+		fmt.Printf("Latest      : %s\n", s.LatestVersion)
+		return
+	}
+
+	fmt.Printf("Releases    : %d\n", s.Count)
+	fmt.Printf("Latest      : %s (%s, %d days ago)\n", s.LatestVersion, s.LatestAt.Format("2006-01-02"), s.DaysSinceLast)
+	if s.CadenceDays > 0 {
+		fmt.Printf("Cadence     : %s (every %.0f days)\n", s.Cadence, s.CadenceDays)
+	} else {
+		fmt.Printf("Cadence     : %s\n", s.Cadence)
+	}
+}
@@ -0,0 +1,201 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// SARIF 2.1.0 identifiers.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "Repo-lyzer"
+	toolURI      = "https://github.com/agnivo988/Repo-lyzer"
+)
+
+// The subset of the SARIF 2.1.0 object model the report uses.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool                     sarifTool             `json:"tool"`
+		VersionControlProvenance []sarifVersionControl `json:"versionControlProvenance,omitempty"`
+		Invocations              []sarifInvocation     `json:"invocations"`
+		Results                  []sarifResult         `json:"results"`
+	}
+
+	sarifInvocation struct {
+		ExecutionSuccessful        bool                `json:"executionSuccessful"`
+		ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+	}
+
+	sarifNotification struct {
+		Level          string              `json:"level"`
+		Message        sarifMessage        `json:"message"`
+		AssociatedRule *sarifRuleReference `json:"associatedRule,omitempty"`
+	}
+
+	sarifRuleReference struct {
+		ID    string `json:"id"`
+		Index int    `json:"index"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID                   string        `json:"id"`
+		Name                 string        `json:"name"`
+		ShortDescription     sarifMessage  `json:"shortDescription"`
+		Help                 *sarifMessage `json:"help,omitempty"`
+		DefaultConfiguration sarifConfig   `json:"defaultConfiguration"`
+	}
+
+	sarifConfig struct {
+		Level string `json:"level"`
+	}
+
+	sarifVersionControl struct {
+		RepositoryURI string `json:"repositoryUri"`
+		Branch        string `json:"branch,omitempty"`
+	}
+
+	sarifResult struct {
+		RuleID    string                `json:"ruleId"`
+		RuleIndex int                   `json:"ruleIndex"`
+		Level     string                `json:"level"`
+		Message   sarifMessage          `json:"message"`
+		Locations []sarifResultLocation `json:"locations,omitempty"`
+	}
+
+	sarifResultLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifLocation `json:"artifactLocation"`
+	}
+
+	sarifLocation struct {
+		URI       string `json:"uri"`
+		URIBaseID string `json:"uriBaseId,omitempty"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+)
+
+// sarifAnchors are the files that repository-level findings, which have no
+// path, are located at: the root file that would resolve them. Code
+// scanning drops results without a location.
+var sarifAnchors = map[string]string{
+	"security-policy":    "SECURITY.md",
+	"dependency-updates": ".github/dependabot.yml",
+}
+
+// SARIFTarget identifies the analyzed repository in a SARIF log.
+type SARIFTarget struct {
+	URL    string // Repository URL, e.g. https://github.com/owner/repo
+	Branch string
+}
+
+// WriteSARIF writes security findings as a SARIF 2.1.0 log for code
+// scanning tools. Every security check becomes a rule; findings become
+// results located at their tree path, relative to the repository root.
+// Findings about the repository as a whole are located at the root file
+// that would resolve them, see sarifAnchors. Skipped checks become
+// notifications of the invocation, so that no result doesn't read as a
+// passed check. A finding of a check that isn't in
+// analyzer.SecurityChecks is an error, since it has no rule to refer to.
+func WriteSARIF(w io.Writer, target SARIFTarget, report analyzer.SecurityReport) error {
+	driver := sarifDriver{Name: toolName, InformationURI: toolURI}
+	ruleIndex := make(map[string]int, len(analyzer.SecurityChecks))
+	for i, check := range analyzer.SecurityChecks {
+		ruleIndex[check.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   check.ID,
+			Name:                 check.Name,
+			ShortDescription:     sarifMessage{Text: check.Name},
+			DefaultConfiguration: sarifConfig{Level: "warning"},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, f := range report.Findings {
+		i, ok := ruleIndex[f.Check]
+		if !ok {
+			return fmt.Errorf("finding of unknown security check %q", f.Check)
+		}
+		if driver.Rules[i].Help == nil {
+			driver.Rules[i].Help = &sarifMessage{Text: f.Remediation}
+		}
+
+		result := sarifResult{
+			RuleID:    f.Check,
+			RuleIndex: i,
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: f.Title + ". " + f.Remediation},
+		}
+		uri := f.Path
+		if uri == "" {
+			uri = sarifAnchors[f.Check]
+		}
+		if uri != "" {
+			result.Locations = []sarifResultLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifLocation{URI: uri, URIBaseID: "%SRCROOT%"},
+				},
+			}}
+		}
+		results = append(results, result)
+	}
+
+	invocation := sarifInvocation{ExecutionSuccessful: true}
+	for _, check := range report.Skipped {
+		if _, ok := ruleIndex[check.ID]; !ok {
+			return fmt.Errorf("skipped unknown security check %q", check.ID)
+		}
+		invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+			Level:          "warning",
+			Message:        sarifMessage{Text: check.Name + " skipped: " + check.Reason},
+			AssociatedRule: &sarifRuleReference{ID: check.ID, Index: ruleIndex[check.ID]},
+		})
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Invocations: []sarifInvocation{invocation}, Results: results}
+	if target.URL != "" {
+		run.VersionControlProvenance = []sarifVersionControl{{RepositoryURI: target.URL, Branch: target.Branch}}
+	}
+
+	log := sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// sarifLevel maps a finding severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case analyzer.SeverityHigh:
+		return "error"
+	case analyzer.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// PrintSecurity prints the security hygiene findings with their fixes.
+func PrintSecurity(r analyzer.SecurityReport) {
+	fmt.Println(SectionStyle.Render("\n🔒 Security Hygiene"))
+
+	for _, check := range r.Passed {
+		fmt.Println(SuccessStyle.Render("✓ " + check.Name))
+	}
+	for _, check := range r.Skipped {
+		fmt.Println(WarningStyle.Render("? " + check.Name + " (skipped, " + check.Reason + ")"))
+	}
+	if len(r.Findings) == 0 {
+		return
+	}
+
+	fmt.Println("Findings    :", r.Summary())
+	for _, f := range r.Findings {
+		style := WarningStyle
+		switch f.Severity {
+		case analyzer.SeverityHigh:
+			style = ErrorStyle
+		case analyzer.SeverityLow:
+			style = lipgloss.NewStyle()
+		}
+
+		title := fmt.Sprintf("[%s] %s", f.Severity, f.Title)
+		if f.Path != "" {
+			title += " (" + f.Path + ")"
+		}
+		fmt.Println(style.Render(title))
+		fmt.Println("   → " + f.Remediation)
+	}
+}
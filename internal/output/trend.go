@@ -0,0 +1,31 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// PrintActivityTrend prints the weekly commit trend and its change points.
+func PrintActivityTrend(t analyzer.ActivityTrend) {
+	fmt.Println(SectionStyle.Render("\n📉 Activity Trend"))
+
+	if t.Direction == analyzer.TrendUnknown {
+		fmt.Println(WarningStyle.Render("Not enough commit history for a trend"))
+		return
+	}
+
+	style := SuccessStyle
+	switch t.Direction {
+	case analyzer.TrendDeclining:
+		style = ErrorStyle
+	case analyzer.TrendStable:
+		style = WarningStyle
+	}
+
+	fmt.Println(style.Render(fmt.Sprintf("Trend       : %s (%.0f%% confidence)", t.Direction, t.Confidence*100)))
+	fmt.Printf("Weekly      : %.1f commits on average, %+.0f%% over %d weeks\n", t.Mean, t.Change*100, len(t.Weeks))
+	for _, c := range t.ChangePoints {
+		fmt.Printf("Change Point: %s\n", c)
+	}
+}
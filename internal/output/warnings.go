@@ -0,0 +1,17 @@
+package output
+
+import "fmt"
+
+// PrintDataWarnings prints what limits the data of an analysis, such as
+// failed fetches or lists cut short by the max-items cap. It prints
+// nothing without warnings.
+func PrintDataWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Println(SectionStyle.Render("\n⚠️ Data Warnings"))
+	for _, w := range warnings {
+		fmt.Println(WarningStyle.Render("• " + w))
+	}
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Batch combines the reports of many repositories.
+type Batch struct {
+	SchemaVersion string    `json:"schema_version" yaml:"schema_version"`
+	GeneratedAt   string    `json:"generated_at" yaml:"generated_at"`
+	WindowDays    int       `json:"window_days" yaml:"window_days"`
+	Reports       []Report  `json:"repositories" yaml:"repositories"`
+	Failures      []Failure `json:"failures" yaml:"failures"`
+}
+
+// Failure is a repository that couldn't be analyzed.
+type Failure struct {
+	Repository string `json:"repository" yaml:"repository"`
+	Error      string `json:"error" yaml:"error"`
+}
+
+// NewBatch returns an empty batch for a window of days.
+func NewBatch(days int) Batch {
+	return Batch{
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   time.Now().Format(time.RFC3339),
+		WindowDays:    days,
+		Reports:       []Report{},
+		Failures:      []Failure{},
+	}
+}
+
+// batchColumns are the columns of the batch CSV, one row per repository.
+var batchColumns = []string{
+	"repository", "status", "health_score", "bus_factor", "bus_risk", "maturity_score", "maturity_level",
+	"commits", "contributors", "stars", "forks", "open_issues", "license", "last_push",
+	"releases", "release_cadence", "pr_health", "issue_health", "activity_trend",
+	"test_ratio", "security", "error",
+}
+
+// WriteJSON writes the batch as indented JSON.
+func (b Batch) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+// WriteCSV writes one row of headline metrics per repository, failed
+// repositories last. Metrics without data are left empty.
+func (b Batch) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write(batchColumns)
+	for _, r := range b.Reports {
+		m := r.Metrics
+		writer.Write([]string{
+			r.Repository.FullName, "ok",
+			fmt.Sprint(m.HealthScore), csvValue(m.BusFactor, "%d"), m.BusRisk,
+			csvValue(m.MaturityScore, "%d"), m.MaturityLevel,
+			csvValue(m.Commits, "%d"), csvValue(m.Contributors, "%d"),
+			fmt.Sprint(r.Repository.Stars), fmt.Sprint(r.Repository.Forks), fmt.Sprint(r.Repository.OpenIssues),
+			r.Repository.License, r.Repository.LastPush,
+			csvValue(m.Releases, "%d"), m.Cadence, m.PRHealth, m.IssueHealth, m.Trend,
+			csvValue(m.TestRatio, "%.2f"), r.Security.Summary, "",
+		})
+	}
+	for _, f := range b.Failures {
+		row := make([]string, len(batchColumns))
+		row[0], row[1], row[len(row)-1] = f.Repository, "failed", f.Error
+		writer.Write(row)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvValue formats a metric with format, leaving metrics without data
+// empty.
+func csvValue[T any](v *T, format string) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf(format, *v)
+}
+
+// ExportPath is where a batch keeps the JSON report of repo (owner/repo)
+// in dir.
+func ExportPath(dir, repo string) string {
+	return filepath.Join(dir, strings.ReplaceAll(repo, "/", "_")+".json")
+}
+
+// Save writes r as JSON to path. The file is replaced atomically, so an
+// interrupted write never leaves a truncated report behind.
+func Save(path string, r Report) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := Write(tmp, r, "json"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads a JSON report written by Save. Reports of another schema
+// version are rejected.
+func Load(path string) (Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Report{}, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Report{}, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	if r.SchemaVersion != SchemaVersion {
+		return Report{}, fmt.Errorf("report %s has schema %q, want %q", path, r.SchemaVersion, SchemaVersion)
+	}
+	return r, nil
+}
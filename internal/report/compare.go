@@ -0,0 +1,197 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// CompareFormats are the formats WriteComparison supports.
+var CompareFormats = []string{"json", "markdown"}
+
+// Comparison is a comparison of any number of repositories.
+type Comparison struct {
+	SchemaVersion string          `json:"schema_version" yaml:"schema_version"`
+	ExportedAt    string          `json:"exported_at" yaml:"exported_at"`
+	WindowDays    int             `json:"window_days" yaml:"window_days"`
+	Profile       Profile         `json:"profile" yaml:"profile"`
+	Repositories  []Report        `json:"repositories" yaml:"repositories"`
+	Rankings      []MetricRanking `json:"rankings" yaml:"rankings"`
+	Overall       []OverallRank   `json:"overall" yaml:"overall"`
+	Verdict       string          `json:"verdict" yaml:"verdict"`
+	Warnings      []Warning       `json:"warnings" yaml:"warnings"`
+}
+
+// Profile is the weight profile a comparison was ranked with.
+type Profile struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// MetricRanking ranks the repositories by one metric.
+type MetricRanking struct {
+	Metric       string        `json:"metric" yaml:"metric"`
+	Name         string        `json:"name" yaml:"name"`
+	HigherBetter bool          `json:"higher_is_better" yaml:"higher_is_better"`
+	Weight       float64       `json:"weight" yaml:"weight"`
+	Entries      []RankedValue `json:"entries" yaml:"entries"` // In repository order
+}
+
+// RankedValue is one repository's value of a metric. Rank is 0 without
+// data.
+type RankedValue struct {
+	Repository string  `json:"repository" yaml:"repository"`
+	Value      float64 `json:"value" yaml:"value"`
+	Known      bool    `json:"known" yaml:"known"`
+	Rank       int     `json:"rank" yaml:"rank"`
+	Best       bool    `json:"best" yaml:"best"`
+	Warning    string  `json:"warning,omitempty" yaml:"warning,omitempty"` // Why there's no data, when a fetch failed
+}
+
+// OverallRank is a repository's place in the weighted overall ranking.
+type OverallRank struct {
+	Rank       int     `json:"rank" yaml:"rank"`
+	Repository string  `json:"repository" yaml:"repository"`
+	Score      float64 `json:"score" yaml:"score"`
+	Rationale  []Win   `json:"rationale" yaml:"rationale"` // The weighted metrics it leads on, heaviest first
+}
+
+// Win is a weighted metric a repository leads on. RunnerUp is empty when
+// no other repository had data.
+type Win struct {
+	Metric      string  `json:"metric" yaml:"metric"`
+	Weight      float64 `json:"weight" yaml:"weight"`
+	Value       float64 `json:"value" yaml:"value"`
+	RunnerUp    string  `json:"runner_up,omitempty" yaml:"runner_up,omitempty"`
+	RunnerValue float64 `json:"runner_up_value,omitempty" yaml:"runner_up_value,omitempty"`
+	Summary     string  `json:"summary" yaml:"summary"`
+}
+
+// BuildComparison flattens analyses and their comparison.
+func BuildComparison(analyses []analyzer.Analysis, c analyzer.Comparison) Comparison {
+	out := Comparison{
+		SchemaVersion: SchemaVersion,
+		ExportedAt:    time.Now().Format(time.RFC3339),
+		Repositories:  []Report{},
+		Rankings:      []MetricRanking{},
+		Profile:       Profile{Name: c.Profile.Name, Description: c.Profile.Description},
+		Overall:       []OverallRank{},
+		Verdict:       c.Verdict(),
+		Warnings:      []Warning{},
+	}
+	for _, a := range analyses {
+		out.WindowDays = a.Days
+		out.Repositories = append(out.Repositories, Build(a))
+	}
+	for _, r := range c.Metrics {
+		ranking := MetricRanking{
+			Metric:       r.Metric.ID,
+			Name:         r.Metric.Name,
+			HigherBetter: r.Metric.HigherBetter,
+			Weight:       r.Weight,
+		}
+		for i, repo := range c.Repos {
+			ranking.Entries = append(ranking.Entries, RankedValue{
+				Repository: repo,
+				Value:      r.Values[i],
+				Known:      r.Known[i],
+				Rank:       r.Ranks[i],
+				Best:       r.IsBest(i),
+				Warning:    r.Warnings[i],
+			})
+		}
+		out.Rankings = append(out.Rankings, ranking)
+	}
+	for _, o := range c.Overall {
+		rank := OverallRank{Rank: o.Rank, Repository: o.Repo, Score: o.Score, Rationale: []Win{}}
+		for _, w := range o.Wins {
+			rank.Rationale = append(rank.Rationale, Win{
+				Metric:      w.Metric.ID,
+				Weight:      w.Weight,
+				Value:       w.Value,
+				RunnerUp:    w.NextRepo,
+				RunnerValue: w.Next,
+				Summary:     w.String(),
+			})
+		}
+		out.Overall = append(out.Overall, rank)
+	}
+	for _, w := range c.Warnings() {
+		out.Warnings = append(out.Warnings, Warning{Repository: w.Repo, Metric: w.Metric.ID, Message: w.Message})
+	}
+	return out
+}
+
+// WriteComparison writes c to w in format, one of CompareFormats.
+func WriteComparison(w io.Writer, c Comparison, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(c)
+	case "markdown":
+		_, err := io.WriteString(w, CompareMarkdown(c))
+		return err
+	default:
+		return fmt.Errorf("unknown comparison format %q (want one of %s)", format, strings.Join(CompareFormats, ", "))
+	}
+}
+
+// CompareMarkdown renders c as a Markdown document with the best value of
+// each metric in bold and the metrics without data because of failed
+// fetches marked ⚠.
+func CompareMarkdown(c Comparison) string {
+	names := make([]string, len(c.Repositories))
+	for i, r := range c.Repositories {
+		names[i] = r.Repository.FullName
+	}
+
+	md := fmt.Sprintf("# Comparison: %s\n\n", strings.Join(names, " vs "))
+	md += fmt.Sprintf("*Exported: %s, last %d days, %s profile (%s), schema %s*\n\n",
+		c.ExportedAt, c.WindowDays, c.Profile.Name, c.Profile.Description, c.SchemaVersion)
+
+	md += "## Metrics\n\n"
+	md += "| Metric | Weight | " + strings.Join(names, " | ") + " |\n"
+	md += "|--------|--------|" + strings.Repeat("--------|", len(names)) + "\n"
+	for _, r := range c.Rankings {
+		metric := analyzer.CompareMetric{ID: r.Metric}
+		cells := make([]string, len(r.Entries))
+		for i, e := range r.Entries {
+			switch {
+			case e.Warning != "":
+				cells[i] = "n/a ⚠"
+			case !e.Known:
+				cells[i] = "n/a"
+			case e.Best:
+				cells[i] = fmt.Sprintf("**%s** (#%d)", metric.Format(e.Value), e.Rank)
+			default:
+				cells[i] = fmt.Sprintf("%s (#%d)", metric.Format(e.Value), e.Rank)
+			}
+		}
+		md += fmt.Sprintf("| %s | %s | %s |\n", r.Name, analyzer.FormatWeight(r.Weight), strings.Join(cells, " | "))
+	}
+
+	md += "\n## Overall Ranking\n\n"
+	for _, o := range c.Overall {
+		md += fmt.Sprintf("%d. %s (%.1f/100)\n", o.Rank, o.Repository, o.Score)
+		for _, w := range o.Rationale {
+			md += fmt.Sprintf("   - %s\n", w.Summary)
+		}
+	}
+
+	if len(c.Warnings) > 0 {
+		md += "\n## Warnings\n\n"
+		md += "Metrics marked ⚠ have no data because a fetch failed:\n\n"
+		for _, w := range c.Warnings {
+			md += fmt.Sprintf("- %s, %s: %s\n", w.Repository, w.Metric, w.Message)
+		}
+	}
+
+	md += "\n## Verdict\n\n"
+	md += c.Verdict + ".\n"
+	return md
+}
@@ -0,0 +1,247 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// Formats are the formats Write supports.
+var Formats = []string{"json", "yaml", "csv", "table", "markdown"}
+
+// Write writes r to w in format, one of Formats.
+//
+// The csv and table formats flatten the report into one row per field,
+// keyed by its dotted JSON path, e.g. "metrics.health_score" or
+// "top_contributors.0.login".
+func Write(w io.Writer, r Report, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case "yaml":
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"field", "value"})
+		for _, f := range Flatten(r) {
+			writer.Write([]string{f.Name, f.Value})
+		}
+		writer.Flush()
+		return writer.Error()
+	case "table":
+		table := tablewriter.NewWriter(w)
+		table.Header([]string{"Field", "Value"})
+		for _, f := range Flatten(r) {
+			table.Append([]string{f.Name, f.Value})
+		}
+		return table.Render()
+	case "markdown":
+		_, err := io.WriteString(w, Markdown(r))
+		return err
+	default:
+		return fmt.Errorf("unknown report format %q (want one of %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// Field is one leaf value of a flattened Report.
+type Field struct {
+	Name  string // Dotted JSON path
+	Value string
+}
+
+// Flatten lists the leaf values of r in schema order. Map keys are sorted.
+func Flatten(r Report) []Field {
+	var fields []Field
+	flatten("", reflect.ValueOf(r), &fields)
+	return fields
+}
+
+func flatten(prefix string, v reflect.Value, fields *[]Field) {
+	join := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "." + name
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, opts, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			if opts == "omitempty" && v.Field(i).IsZero() {
+				continue
+			}
+			flatten(join(name), v.Field(i), fields)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			flatten(join(fmt.Sprint(i)), v.Index(i), fields)
+		}
+	case reflect.Map:
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			flatten(join(k), v.MapIndex(reflect.ValueOf(k)), fields)
+		}
+	case reflect.Pointer:
+		// Metrics without data flatten to an empty value
+		if v.IsNil() {
+			*fields = append(*fields, Field{Name: prefix})
+			return
+		}
+		flatten(prefix, v.Elem(), fields)
+	case reflect.Float32, reflect.Float64:
+		*fields = append(*fields, Field{Name: prefix, Value: fmt.Sprintf("%.4g", v.Float())})
+	default:
+		*fields = append(*fields, Field{Name: prefix, Value: fmt.Sprint(v.Interface())})
+	}
+}
+
+// mdValue formats a metric of a Report with format, flagging metrics
+// without data.
+func mdValue[T any](v *T, format string) string {
+	if v == nil {
+		return "n/a ⚠"
+	}
+	return fmt.Sprintf(format, *v)
+}
+
+// Markdown renders r as a Markdown document.
+func Markdown(r Report) string {
+	repo, m := r.Repository, r.Metrics
+
+	md := fmt.Sprintf("# Analysis for %s\n\n", repo.FullName)
+	md += fmt.Sprintf("*Exported: %s, last %d days, schema %s*\n\n", r.ExportedAt, r.WindowDays, r.SchemaVersion)
+
+	md += "## Repository Info\n"
+	md += fmt.Sprintf("- **Stars:** %d\n", repo.Stars)
+	md += fmt.Sprintf("- **Forks:** %d\n", repo.Forks)
+	md += fmt.Sprintf("- **Open Issues:** %d\n", repo.OpenIssues)
+	md += fmt.Sprintf("- **Created:** %s\n", repo.CreatedAt)
+	if repo.License != "" {
+		md += fmt.Sprintf("- **License:** %s\n", repo.License)
+	}
+	if len(repo.Topics) > 0 {
+		md += fmt.Sprintf("- **Topics:** %s\n", strings.Join(repo.Topics, ", "))
+	}
+	md += fmt.Sprintf("- **URL:** %s\n\n", repo.URL)
+
+	md += "## Metrics\n"
+	md += fmt.Sprintf("- **Health Score:** %d/100\n", m.HealthScore)
+	md += fmt.Sprintf("- **Bus Factor:** %s (%s)\n", mdValue(m.BusFactor, "%d"), m.BusRisk)
+	if len(m.BusMembers) > 0 {
+		md += fmt.Sprintf("- **Key Contributors:** %s\n", strings.Join(m.BusMembers, ", "))
+	}
+	md += fmt.Sprintf("- **Maturity:** %s (%s)\n", m.MaturityLevel, mdValue(m.MaturityScore, "%d"))
+	md += fmt.Sprintf("- **Releases:** %s (%s)\n", mdValue(m.Releases, "%d"), m.Cadence)
+	md += fmt.Sprintf("- **Issues:** %s\n", m.IssueHealth)
+	md += fmt.Sprintf("- **Pull Requests:** %s\n", m.PRHealth)
+	md += fmt.Sprintf("- **Commits (%d days):** %s\n", r.WindowDays, mdValue(m.Commits, "%d"))
+	trend := m.Trend
+	switch {
+	case m.TrendConf == nil:
+		trend = mdValue(m.TrendConf, "")
+	case trend != analyzer.TrendUnknown:
+		trend = fmt.Sprintf("%s (%.0f%% confidence)", m.Trend, *m.TrendConf*100)
+	}
+	md += fmt.Sprintf("- **Activity Trend:** %s\n", trend)
+	for _, c := range m.ChangePoints {
+		md += fmt.Sprintf("  - Change point %s\n", c)
+	}
+	md += fmt.Sprintf("- **Contributors:** %s\n", mdValue(m.Contributors, "%d"))
+	if m.Files != nil {
+		md += fmt.Sprintf("- **Files:** %d (%s), test ratio %s, max depth %s\n\n",
+			*m.Files, analyzer.FormatBytes(*m.TotalBytes), mdValue(m.TestRatio, "%.2f"), mdValue(m.MaxDepth, "%d"))
+	} else {
+		md += "- **Files:** " + mdValue(m.Files, "") + "\n\n"
+	}
+
+	md += "## Health Score Breakdown\n"
+	md += "| Rule | Points | Why |\n|------|--------|-----|\n"
+	md += fmt.Sprintf("| base | %+.1f | base score |\n", m.HealthBase)
+	for _, rule := range r.HealthBreakdown {
+		md += fmt.Sprintf("| %s | %+.1f / %.0f | %s |\n", rule.Rule, rule.Points, rule.Max, rule.Reason)
+	}
+	md += "\n"
+
+	md += "## Security Hygiene\n"
+	md += fmt.Sprintf("**Findings:** %s\n\n", r.Security.Summary)
+	if len(r.Security.Findings) > 0 {
+		md += "| Severity | Finding | Path | Remediation |\n|----------|---------|------|-------------|\n"
+		for _, f := range r.Security.Findings {
+			md += fmt.Sprintf("| %s | %s | %s | %s |\n", f.Severity, f.Title, f.Path, f.Remediation)
+		}
+		md += "\n"
+	}
+
+	md += "## Languages\n"
+	total := 0
+	langs := make([]string, 0, len(r.Languages))
+	for lang, bytes := range r.Languages {
+		total += bytes
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return r.Languages[langs[i]] > r.Languages[langs[j]] })
+	for _, lang := range langs {
+		pct := float64(r.Languages[lang]) / float64(total) * 100
+		md += fmt.Sprintf("- %s: %.1f%%\n", lang, pct)
+	}
+	md += "\n"
+
+	md += "## Top Contributors\n"
+	for i, c := range r.TopContributors {
+		md += fmt.Sprintf("%d. %s (%d commits)\n", i+1, c.Login, c.Commits)
+	}
+
+	if o := r.FileOwnership; o != nil {
+		md += fmt.Sprintf("\n## File Ownership\n*%d files changed by the newest %d commits*\n\n", o.Files, o.Commits)
+		for i, owner := range o.Owners {
+			md += fmt.Sprintf("%d. %s (%d files)\n", i+1, owner.Login, owner.Files)
+		}
+	}
+
+	if len(r.Warnings) > 0 || len(r.Truncated) > 0 {
+		md += "\n## Warnings\n"
+	}
+	if len(r.Warnings) > 0 {
+		md += "These metrics have no data because a fetch failed:\n\n"
+		for _, w := range r.Warnings {
+			md += fmt.Sprintf("- %s: %s\n", w.Metric, w.Message)
+		}
+	}
+	if len(r.Truncated) > 0 {
+		md += "\nThese lists were cut short by the max-items cap, so counts based on them are too low:\n\n"
+		tasks := make([]string, 0, len(r.Truncated))
+		for task := range r.Truncated {
+			tasks = append(tasks, task)
+		}
+		sort.Strings(tasks)
+		for _, task := range tasks {
+			md += fmt.Sprintf("- %s: first %d items\n", task, r.Truncated[task])
+		}
+	}
+	return md
+}
@@ -0,0 +1,300 @@
+// Package report defines the machine-readable analysis report shared by
+// the CLI output formats and the dashboard exports.
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+)
+
+// SchemaVersion is the version of the Report schema. It changes whenever
+// a field is renamed or removed; new fields don't change it.
+const SchemaVersion = "1.0"
+
+// topContributors is how many contributors a Report lists.
+const topContributors = 10
+
+// Report is the analysis of one repository in the shape every format
+// shares.
+type Report struct {
+	SchemaVersion   string         `json:"schema_version" yaml:"schema_version"`
+	ExportedAt      string         `json:"exported_at" yaml:"exported_at"`
+	WindowDays      int            `json:"window_days" yaml:"window_days"`
+	Repository      Repository     `json:"repository" yaml:"repository"`
+	Metrics         Metrics        `json:"metrics" yaml:"metrics"`
+	Languages       map[string]int `json:"languages" yaml:"languages"`
+	TopContributors []Contributor  `json:"top_contributors" yaml:"top_contributors"`
+	FileOwnership   *FileOwnership `json:"file_ownership,omitempty" yaml:"file_ownership,omitempty"` // Only when commit files were fetched
+	HealthBreakdown []Rule         `json:"health_breakdown" yaml:"health_breakdown"`
+	Security        Security       `json:"security" yaml:"security"`
+	Warnings        []Warning      `json:"warnings,omitempty" yaml:"warnings,omitempty"`   // Metrics failed fetches left without data, null in Metrics
+	Truncated       map[string]int `json:"truncated,omitempty" yaml:"truncated,omitempty"` // Lists cut short by the max-items cap, with the cap
+}
+
+// Warning is a metric a repository has no data for because a fetch
+// failed.
+type Warning struct {
+	Repository string `json:"repository" yaml:"repository"`
+	Metric     string `json:"metric" yaml:"metric"`
+	Message    string `json:"message" yaml:"message"`
+}
+
+type Repository struct {
+	FullName      string   `json:"full_name" yaml:"full_name"`
+	Description   string   `json:"description" yaml:"description"`
+	Stars         int      `json:"stars" yaml:"stars"`
+	Forks         int      `json:"forks" yaml:"forks"`
+	OpenIssues    int      `json:"open_issues" yaml:"open_issues"`
+	CreatedAt     string   `json:"created_at" yaml:"created_at"`
+	LastPush      string   `json:"last_push" yaml:"last_push"`
+	DefaultBranch string   `json:"default_branch" yaml:"default_branch"`
+	URL           string   `json:"url" yaml:"url"`
+	License       string   `json:"license,omitempty" yaml:"license,omitempty"` // SPDX identifier
+	Topics        []string `json:"topics,omitempty" yaml:"topics,omitempty"`
+}
+
+// Metrics are the headline metrics. The pointers are nil, null in JSON and
+// YAML, when a failed fetch left the metric without data, see Warnings.
+type Metrics struct {
+	HealthScore   int      `json:"health_score" yaml:"health_score"`
+	HealthBase    float64  `json:"health_base" yaml:"health_base"` // Points before the health rules
+	Commits       *int     `json:"commits" yaml:"commits"`
+	Contributors  *int     `json:"contributors" yaml:"contributors"`
+	BusFactor     *int     `json:"bus_factor" yaml:"bus_factor"`
+	BusRisk       string   `json:"bus_risk" yaml:"bus_risk"`
+	BusMembers    []string `json:"bus_factor_members" yaml:"bus_factor_members"`
+	BusMethod     string   `json:"bus_factor_method" yaml:"bus_factor_method"`
+	MaturityScore *int     `json:"maturity_score" yaml:"maturity_score"`
+	MaturityLevel string   `json:"maturity_level" yaml:"maturity_level"`
+	Releases      *int     `json:"releases" yaml:"releases"`
+	LatestRelease string   `json:"latest_release,omitempty" yaml:"latest_release,omitempty"`
+	Cadence       string   `json:"release_cadence" yaml:"release_cadence"`
+	PRHealth      string   `json:"pr_health" yaml:"pr_health"`
+	IssueHealth   string   `json:"issue_health" yaml:"issue_health"`
+	MergeRatio    *float64 `json:"pr_merge_ratio" yaml:"pr_merge_ratio"`
+	Trend         string   `json:"activity_trend" yaml:"activity_trend"`
+	TrendConf     *float64 `json:"activity_trend_confidence" yaml:"activity_trend_confidence"`
+	ChangePoints  []string `json:"activity_change_points,omitempty" yaml:"activity_change_points,omitempty"`
+	Files         *int     `json:"files" yaml:"files"`
+	TotalBytes    *int     `json:"total_bytes" yaml:"total_bytes"`
+	TestRatio     *float64 `json:"test_ratio" yaml:"test_ratio"`
+	MaxDepth      *int     `json:"max_depth" yaml:"max_depth"`
+}
+
+// Rule is one health rule of the score breakdown.
+type Rule struct {
+	Rule   string  `json:"rule" yaml:"rule"`
+	Metric string  `json:"metric" yaml:"metric"`
+	Value  float64 `json:"value" yaml:"value"`
+	Known  bool    `json:"known" yaml:"known"`
+	Points float64 `json:"points" yaml:"points"`
+	Max    float64 `json:"max_points" yaml:"max_points"`
+	Passed bool    `json:"passed" yaml:"passed"`
+	Reason string  `json:"reason" yaml:"reason"`
+}
+
+// Security holds the security hygiene findings.
+type Security struct {
+	Summary  string            `json:"summary" yaml:"summary"`
+	Passed   []string          `json:"passed_checks" yaml:"passed_checks"`
+	Skipped  []string          `json:"skipped_checks,omitempty" yaml:"skipped_checks,omitempty"` // Not run for lack of complete data
+	Reasons  map[string]string `json:"skip_reasons,omitempty" yaml:"skip_reasons,omitempty"`     // Why, keyed by skipped check
+	Findings []Finding         `json:"findings" yaml:"findings"`
+}
+
+// Finding is one security finding.
+type Finding struct {
+	Check       string `json:"check" yaml:"check"`
+	Severity    string `json:"severity" yaml:"severity"`
+	Title       string `json:"title" yaml:"title"`
+	Path        string `json:"path,omitempty" yaml:"path,omitempty"`
+	Remediation string `json:"remediation" yaml:"remediation"`
+}
+
+type Contributor struct {
+	Login   string `json:"login" yaml:"login"`
+	Commits int    `json:"commits" yaml:"commits"`
+}
+
+// FileOwnership lists who owns the files the newest commits changed.
+type FileOwnership struct {
+	Commits int         `json:"commits" yaml:"commits"`
+	Files   int         `json:"files" yaml:"files"`
+	Owners  []FileOwner `json:"owners" yaml:"owners"`
+}
+
+// FileOwner is a contributor and the number of files they own.
+type FileOwner struct {
+	Login string `json:"login" yaml:"login"`
+	Files int    `json:"files" yaml:"files"`
+}
+
+// Build flattens an analysis into a Report.
+func Build(a analyzer.Analysis) Report {
+	var contributors []Contributor
+	for i, c := range a.Contributors {
+		if i == topContributors {
+			break
+		}
+		contributors = append(contributors, Contributor{Login: c.Login, Commits: c.Commits})
+	}
+
+	license := ""
+	if a.Repo.License != nil {
+		license = a.Repo.License.SPDXID
+	}
+
+	// The file metrics come from the file tree, like test_ratio
+	missing := make(map[string]bool)
+	for _, w := range a.MetricWarnings() {
+		missing[w.Metric] = true
+	}
+	tree := !missing["test_ratio"]
+
+	return Report{
+		SchemaVersion: SchemaVersion,
+		ExportedAt:    time.Now().Format(time.RFC3339),
+		WindowDays:    a.Days,
+		Repository: Repository{
+			FullName:      a.Repo.FullName,
+			Description:   a.Repo.Description,
+			Stars:         a.Repo.Stars,
+			Forks:         a.Repo.Forks,
+			OpenIssues:    a.Repo.OpenIssues,
+			CreatedAt:     a.Repo.CreatedAt.Format("2006-01-02"),
+			LastPush:      a.Repo.PushedAt.Format("2006-01-02"),
+			DefaultBranch: a.Repo.DefaultBranch,
+			URL:           a.Repo.HTMLURL,
+			License:       license,
+			Topics:        a.Repo.Topics,
+		},
+		Metrics: Metrics{
+			HealthScore:   a.HealthScore,
+			HealthBase:    a.Health.Base,
+			Commits:       known(len(a.Commits), !missing["commits"]),
+			Contributors:  known(len(a.Contributors), !missing["contributors"]),
+			BusFactor:     known(a.BusFactor.Count, !missing["bus_factor"]),
+			BusRisk:       a.BusFactor.Risk,
+			BusMembers:    a.BusFactor.Members,
+			BusMethod:     a.BusFactor.Method,
+			MaturityScore: known(a.MaturityScore, !missing["maturity_score"]),
+			MaturityLevel: a.MaturityLevel,
+			Releases:      known(a.Releases.Count, !missing["releases"]),
+			LatestRelease: a.Releases.LatestVersion,
+			Cadence:       a.Releases.Cadence,
+			PRHealth:      a.PullRequests.Health,
+			IssueHealth:   a.Issues.Health,
+			MergeRatio:    known(a.PullRequests.MergeRatio, !missing["pr_merge_ratio"]),
+			Trend:         a.Trend.Direction,
+			TrendConf:     known(a.Trend.Confidence, !missing["activity_trend"]),
+			ChangePoints:  changePoints(a.Trend.ChangePoints),
+			Files:         known(a.Files.Files, tree),
+			TotalBytes:    known(a.Files.TotalBytes, tree),
+			TestRatio:     known(a.Files.TestRatio, tree),
+			MaxDepth:      known(a.Files.MaxDepth, tree),
+		},
+		Languages:       a.Languages,
+		TopContributors: contributors,
+		FileOwnership:   fileOwnership(a.FileOwnership),
+		HealthBreakdown: rules(a.Health),
+		Security:        security(a.Security),
+		Warnings:        warnings(a),
+		Truncated:       a.Truncated,
+	}
+}
+
+// fileOwnership converts the file ownership of an analysis, nil when it
+// wasn't requested.
+func fileOwnership(o *analyzer.FileOwnership) *FileOwnership {
+	if o == nil {
+		return nil
+	}
+	ownership := &FileOwnership{Commits: o.Commits, Files: o.Files}
+	for _, owner := range o.Owners {
+		ownership.Owners = append(ownership.Owners, FileOwner{Login: owner.Login, Files: owner.Files})
+	}
+	return ownership
+}
+
+// known returns a pointer to v, or nil when it has no data.
+func known[T any](v T, ok bool) *T {
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// FormatValue prints a metric, or "n/a" when it has no data.
+func FormatValue[T any](v *T) string {
+	if v == nil {
+		return "n/a"
+	}
+	return fmt.Sprint(*v)
+}
+
+// warnings lists the metrics of a that failed fetches left without data.
+func warnings(a analyzer.Analysis) []Warning {
+	var out []Warning
+	for _, w := range a.MetricWarnings() {
+		out = append(out, Warning{Repository: a.Repo.FullName, Metric: w.Metric, Message: w.Err.Error()})
+	}
+	return out
+}
+
+// changePoints describes each change point.
+func changePoints(points []analyzer.ChangePoint) []string {
+	var out []string
+	for _, c := range points {
+		out = append(out, c.String())
+	}
+	return out
+}
+
+// rules flattens the health score breakdown.
+func rules(health analyzer.HealthReport) []Rule {
+	out := make([]Rule, 0, len(health.Rules))
+	for _, r := range health.Rules {
+		out = append(out, Rule{
+			Rule:   r.Rule.Name,
+			Metric: r.Rule.Metric,
+			Value:  r.Value,
+			Known:  r.Known,
+			Points: r.Points,
+			Max:    r.Rule.Points,
+			Passed: r.Passed,
+			Reason: r.Reason,
+		})
+	}
+	return out
+}
+
+// security flattens the security report.
+func security(sec analyzer.SecurityReport) Security {
+	out := Security{
+		Summary:  sec.Summary(),
+		Passed:   []string{},
+		Findings: []Finding{},
+	}
+	for _, check := range sec.Passed {
+		out.Passed = append(out.Passed, check.ID)
+	}
+	for _, check := range sec.Skipped {
+		out.Skipped = append(out.Skipped, check.ID)
+		if out.Reasons == nil {
+			out.Reasons = make(map[string]string)
+		}
+		out.Reasons[check.ID] = check.Reason
+	}
+	for _, f := range sec.Findings {
+		out.Findings = append(out.Findings, Finding{
+			Check:       f.Check,
+			Severity:    f.Severity,
+			Title:       f.Title,
+			Path:        f.Path,
+			Remediation: f.Remediation,
+		})
+	}
+	return out
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"fmt"
+
 	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/agnivo988/Repo-lyzer/internal/github"
 )
@@ -17,6 +19,8 @@ type AnalyzerDataBridge struct {
 	maturityScore int
 	maturityLevel string
 	fileTree      *FileNode
+	trend         analyzer.ActivityTrend
+	days          int // Analysis window the commits were fetched for
 	cache         map[string]interface{}
 }
 
@@ -28,11 +32,13 @@ func NewAnalyzerDataBridge(result AnalysisResult) *AnalyzerDataBridge {
 		contributors:  result.Contributors,
 		languages:     result.Languages,
 		healthScore:   result.HealthScore,
-		busFactor:     result.BusFactor,
-		busRisk:       result.BusRisk,
+		busFactor:     result.BusFactor.Count,
+		busRisk:       result.BusFactor.Risk,
 		maturityScore: result.MaturityScore,
 		maturityLevel: result.MaturityLevel,
 		fileTree:      BuildFileTree(result),
+		trend:         result.Trend,
+		days:          result.Days,
 	}
 }
 
@@ -86,6 +92,8 @@ func (b *AnalyzerDataBridge) GetCommitMetrics() map[string]interface{} {
 		"commit_frequency": b.calculateCommitFrequency(),
 		"last_commit":      b.getLastCommitInfo(),
 		"activity_trend":   b.calculateActivityTrend(),
+		"trend_confidence": b.trend.Confidence,
+		"change_points":    b.trend.ChangePoints,
 	}
 }
 
@@ -139,10 +147,14 @@ func (b *AnalyzerDataBridge) getHealthColor() string {
 	return "red"
 }
 
+// getRiskColor colors the bus factor like its risk label; a bus factor of
+// zero is unknown rather than high risk.
 func (b *AnalyzerDataBridge) getRiskColor() string {
-	if b.busFactor >= 7 {
-		return "green"
+	if b.busFactor == 0 {
+		return "gray"
 	} else if b.busFactor >= 4 {
+		return "green"
+	} else if b.busFactor >= 2 {
 		return "yellow"
 	}
 	return "red"
@@ -185,13 +197,17 @@ func (b *AnalyzerDataBridge) calculateDiversity() float64 {
 	return (1 - diversity) * 100
 }
 
+// getRecentActivity returns the commits of the last 12 weeks, keyed by
+// the Monday each week starts on.
 func (b *AnalyzerDataBridge) getRecentActivity() map[string]int {
 	activity := make(map[string]int)
-	if len(b.commits) == 0 {
-		return activity
+	weeks := b.trend.Weeks
+	if len(weeks) > 12 {
+		weeks = weeks[len(weeks)-12:]
+	}
+	for _, w := range weeks {
+		activity[w.Start.Format("2006-01-02")] = w.Commits
 	}
-
-	// This would be implemented based on commit timestamps
 	return activity
 }
 
@@ -201,7 +217,7 @@ func (b *AnalyzerDataBridge) calculateCommitFrequency() string {
 	}
 
 	// Simplified frequency calculation
-	avgPerDay := float64(len(b.commits)) / 365
+	avgPerDay := float64(len(b.commits)) / float64(max(b.days, 1))
 
 	if avgPerDay >= 10 {
 		return "Very High"
@@ -227,12 +243,10 @@ func (b *AnalyzerDataBridge) getLastCommitInfo() map[string]interface{} {
 }
 
 func (b *AnalyzerDataBridge) calculateActivityTrend() string {
-	if len(b.commits) < 2 {
-		return "Unknown"
+	if len(b.commits) < 2 || b.trend.Direction == "" {
+		return analyzer.TrendUnknown
 	}
-
-	// Simplified trend calculation
-	return "Stable"
+	return b.trend.Direction
 }
 
 func (b *AnalyzerDataBridge) getPrimaryLanguage() string {
@@ -283,9 +297,9 @@ func (b *AnalyzerDataBridge) GenerateSummary() string {
 	}
 
 	// Bus factor assessment
-	if b.busFactor <= 2 {
+	if b.busFactor <= 1 {
 		summary += "🚌 WARNING: High dependency on few contributors.\n"
-	} else if b.busFactor <= 4 {
+	} else if b.busFactor <= 3 {
 		summary += "⚠️ Some concentration of key contributors.\n"
 	} else {
 		summary += "✅ Good distribution of contributor responsibility.\n"
@@ -303,6 +317,21 @@ func (b *AnalyzerDataBridge) GenerateSummary() string {
 		summary += "→ Sporadic update activity.\n"
 	}
 
+	// Trend assessment
+	switch b.calculateActivityTrend() {
+	case analyzer.TrendGrowing:
+		summary += fmt.Sprintf("📈 Activity is growing (%.0f%% confidence).\n", b.trend.Confidence*100)
+	case analyzer.TrendDeclining:
+		summary += fmt.Sprintf("📉 Activity is declining (%.0f%% confidence).\n", b.trend.Confidence*100)
+	case analyzer.TrendStable:
+		summary += "→ Activity is stable.\n"
+	}
+	for _, c := range b.trend.ChangePoints {
+		if c.Drop() {
+			summary += "⚠️ Activity dropped abruptly in the week of " + c.Week.Format("2006-01-02") + ".\n"
+		}
+	}
+
 	// Maturity assessment
 	summary += "📚 Maturity Level: " + b.maturityLevel + "\n"
 
@@ -320,7 +349,7 @@ func (b *AnalyzerDataBridge) GenerateRecommendations() []string {
 	}
 
 	// Bus factor recommendations
-	if b.busFactor <= 2 {
+	if b.busFactor <= 1 {
 		recommendations = append(recommendations, "Recruit and onboard more contributors")
 		recommendations = append(recommendations, "Document critical processes and architecture")
 	}
@@ -331,6 +360,11 @@ func (b *AnalyzerDataBridge) GenerateRecommendations() []string {
 		recommendations = append(recommendations, "Plan and track issues more systematically")
 	}
 
+	// Trend-based recommendations
+	if b.calculateActivityTrend() == analyzer.TrendDeclining {
+		recommendations = append(recommendations, "Find out why activity is declining and whether key maintainers have left")
+	}
+
 	// Language diversity recommendations
 	diversity := b.calculateLanguageDiversity()
 	if diversity > 70 {
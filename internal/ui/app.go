@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
@@ -29,13 +32,18 @@ const (
 	stateCompareResult
 )
 
+// maxCompareRepos is how many repositories one comparison takes.
+const maxCompareRepos = 8
+
 type MainModel struct {
 	state          sessionState
 	menu           MenuModel
-	input          string // Repository input
-	compareInput1  string // First repo for comparison
-	compareInput2  string // Second repo for comparison
-	compareStep    int    // 0 = entering first repo, 1 = entering second repo
+	input          string              // Repository input
+	compareRepos   []string            // Repos entered for comparison
+	compareInput   string              // Repo being entered for comparison
+	fetchOpts      github.FetchOptions // Analysis window and samples of every fetch
+	healthModel    analyzer.HealthModel
+	clientOpts     []github.Option // Options of every GitHub client the dashboard creates
 	spinner        spinner.Model
 	dashboard      DashboardModel
 	tree           TreeModel
@@ -47,14 +55,31 @@ type MainModel struct {
 	windowHeight   int
 	analysisType   string // quick, detailed, custom
 	appSettings    tea.LogOptionsSetter
-	compareResult  *CompareResult // Holds comparison data
-	history        *History       // Analysis history
-	historyCursor  int            // Current selection in history
-	helpContent    string         // Content for help screen
-	settingsOption string         // Selected settings option
+	compareResult  *CompareResult     // Holds comparison data
+	history        *History           // Analysis history
+	historyCursor  int                // Current selection in history
+	helpContent    string             // Content for help screen
+	settingsOption string             // Selected settings option
+	cancelRequest  context.CancelFunc // Aborts the in-flight analysis or comparison
+	requestID      int                // ID of the latest request, see requestMsg
+}
+
+// requestMsg is the result of an analysis or comparison, tagged with the
+// ID beginRequest gave its request. Results of earlier requests, which
+// were canceled or replaced, are dropped.
+type requestMsg struct {
+	id  int
+	msg tea.Msg
 }
 
-func NewMainModel() MainModel {
+// NewMainModel creates the dashboard, fetching repositories with fetch,
+// health scored by model and GitHub clients configured by opts; a
+// non-positive fetch.CommitDays selects analyzer.DefaultDays.
+func NewMainModel(fetch github.FetchOptions, model analyzer.HealthModel, opts ...github.Option) MainModel {
+	if fetch.CommitDays <= 0 {
+		fetch.CommitDays = analyzer.DefaultDays
+	}
+
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
@@ -66,9 +91,17 @@ func NewMainModel() MainModel {
 		dashboard:   NewDashboardModel(),
 		tree:        NewTreeModel(nil),
 		appSettings: nil,
+		fetchOpts:   fetch,
+		healthModel: model,
+		clientOpts:  opts,
 	}
 }
 
+// newClient creates a GitHub client configured like the command line.
+func (m MainModel) newClient() *github.Client {
+	return github.NewClient(m.clientOpts...)
+}
+
 func (m MainModel) Init() tea.Cmd {
 	return m.spinner.Tick
 }
@@ -77,6 +110,13 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	if r, ok := msg.(requestMsg); ok {
+		if r.id != m.requestID {
+			return m, nil
+		}
+		msg = r.msg
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.windowWidth = msg.Width
@@ -96,6 +136,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" {
+			m.cancelRequests()
 			return m, tea.Quit
 		}
 		// Global shortcuts
@@ -121,7 +162,8 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Re-analyze the current repo
 			if m.dashboard.data.Repo != nil {
 				m.state = stateLoading
-				cmds = append(cmds, m.analyzeRepo(m.dashboard.data.Repo.FullName))
+				ctx, id := m.beginRequest()
+				cmds = append(cmds, m.analyzeRepo(ctx, id, m.dashboard.data.Repo.FullName))
 			}
 		}
 	}
@@ -146,9 +188,8 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.menu.Done = false
 			case 1: // Compare
 				m.state = stateCompareInput
-				m.compareStep = 0
-				m.compareInput1 = ""
-				m.compareInput2 = ""
+				m.compareRepos = nil
+				m.compareInput = ""
 				m.menu.Done = false
 			case 2: // History
 				m.state = stateHistory
@@ -192,7 +233,8 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.input = cleanInput
 					m.err = nil
 					m.state = stateLoading
-					cmds = append(cmds, m.analyzeRepo(cleanInput))
+					ctx, id := m.beginRequest()
+					cmds = append(cmds, m.analyzeRepo(ctx, id, cleanInput))
 				} else {
 					m.err = fmt.Errorf("please enter a valid repository (owner/repo or GitHub URL)")
 				}
@@ -228,66 +270,45 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyMsg:
 			switch msg.Type {
 			case tea.KeyEnter:
-				if m.compareStep == 0 && m.compareInput1 != "" {
-					// Sanitize first repo
-					m.compareInput1 = sanitizeRepoInput(m.compareInput1)
-					m.compareStep = 1
-
-				} else if m.compareStep == 1 && m.compareInput2 != "" {
-					// Sanitize both repos before comparison
-					m.compareInput1 = sanitizeRepoInput(m.compareInput1)
-					m.compareInput2 = sanitizeRepoInput(m.compareInput2)
-
+				if m.compareInput != "" {
+					// Add the repo; Enter on an empty input starts the comparison
+					if repo := sanitizeRepoInput(m.compareInput); repo != "" && len(m.compareRepos) < maxCompareRepos {
+						m.compareRepos = append(m.compareRepos, repo)
+					}
+					m.compareInput = ""
+				} else if len(m.compareRepos) >= 2 {
 					m.err = nil
 					m.state = stateCompareLoading
-					cmds = append(cmds, m.compareRepos(m.compareInput1, m.compareInput2))
+					ctx, id := m.beginRequest()
+					cmds = append(cmds, m.compareReposCmd(ctx, id, m.compareRepos))
 				}
 
 			case tea.KeyBackspace:
-				if m.compareStep == 0 && len(m.compareInput1) > 0 {
-					m.compareInput1 = m.compareInput1[:len(m.compareInput1)-1]
-				} else if m.compareStep == 1 && len(m.compareInput2) > 0 {
-					m.compareInput2 = m.compareInput2[:len(m.compareInput2)-1]
+				if len(m.compareInput) > 0 {
+					m.compareInput = m.compareInput[:len(m.compareInput)-1]
 				}
 			case tea.KeyRunes:
-				if m.compareStep == 0 {
-					m.compareInput1 += string(msg.Runes)
-				} else {
-					m.compareInput2 += string(msg.Runes)
-				}
+				m.compareInput += string(msg.Runes)
 			case tea.KeyEsc:
-				if m.compareStep == 1 {
-					// Go back to first repo input
-					m.compareStep = 0
+				if len(m.compareRepos) > 0 {
+					// Take back the last added repo
+					m.compareInput = m.compareRepos[len(m.compareRepos)-1]
+					m.compareRepos = m.compareRepos[:len(m.compareRepos)-1]
 				} else {
 					m.state = stateMenu
 					m.menu.Done = false
-					m.compareInput1 = ""
-					m.compareInput2 = ""
+					m.compareInput = ""
 				}
 			case tea.KeyCtrlU:
 				// Clear current input
-				if m.compareStep == 0 {
-					m.compareInput1 = ""
-				} else {
-					m.compareInput2 = ""
-				}
+				m.compareInput = ""
 			case tea.KeyCtrlW:
 				// Delete word backward
-				if m.compareStep == 0 {
-					m.compareInput1 = strings.TrimRight(m.compareInput1, " ")
-					if idx := strings.LastIndex(m.compareInput1, " "); idx >= 0 {
-						m.compareInput1 = m.compareInput1[:idx+1]
-					} else {
-						m.compareInput1 = ""
-					}
+				m.compareInput = strings.TrimRight(m.compareInput, " ")
+				if idx := strings.LastIndex(m.compareInput, " "); idx >= 0 {
+					m.compareInput = m.compareInput[:idx+1]
 				} else {
-					m.compareInput2 = strings.TrimRight(m.compareInput2, " ")
-					if idx := strings.LastIndex(m.compareInput2, " "); idx >= 0 {
-						m.compareInput2 = m.compareInput2[:idx+1]
-					} else {
-						m.compareInput2 = ""
-					}
+					m.compareInput = ""
 				}
 			}
 		}
@@ -298,18 +319,23 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg := msg.(type) {
 		case CompareResult:
+			m.cancelRequests()
 			m.compareResult = &msg
 			m.state = stateCompareResult
 			m.err = nil
 		case error:
+			if errors.Is(msg, context.Canceled) {
+				break
+			}
+			m.cancelRequests()
 			m.err = msg
 			m.state = stateCompareInput
-			m.compareStep = 0
 		case tea.KeyMsg:
 			if msg.String() == "esc" {
+				m.cancelRequests()
 				m.state = stateMenu
-				m.compareInput1 = ""
-				m.compareInput2 = ""
+				m.compareRepos = nil
+				m.compareInput = ""
 				m.err = nil
 			}
 		}
@@ -321,8 +347,14 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "q", "esc":
 				m.state = stateMenu
 				m.compareResult = nil
-				m.compareInput1 = ""
-				m.compareInput2 = ""
+				m.compareRepos = nil
+				m.compareInput = ""
+			case "p":
+				// Rank again with the next weight profile
+				if m.compareResult != nil {
+					next := m.compareResult.nextProfile()
+					m.compareResult = &next
+				}
 			case "j":
 				// Export comparison to JSON
 				if m.compareResult != nil {
@@ -354,6 +386,7 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 
 		if result, ok := msg.(AnalysisResult); ok {
+			m.cancelRequests()
 			m.dashboard.SetData(result)
 			m.state = stateDashboard
 			m.progress = nil
@@ -364,11 +397,19 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.history.AddEntry(result)
 			m.history.Save()
 		}
-		if err, ok := msg.(error); ok {
+		if err, ok := msg.(error); ok && !errors.Is(err, context.Canceled) {
+			m.cancelRequests()
 			m.err = err
 			m.state = stateInput // Go back to input on error
 			m.progress = nil
 		}
+		if key, ok := msg.(tea.KeyMsg); ok && key.String() == "esc" {
+			// Abort every in-flight request and return to the menu
+			m.cancelRequests()
+			m.state = stateMenu
+			m.progress = nil
+			m.err = nil
+		}
 
 	case stateHistory:
 		switch msg := msg.(type) {
@@ -388,7 +429,8 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					repoName := m.history.Entries[m.historyCursor].RepoName
 					m.input = repoName
 					m.state = stateLoading
-					cmds = append(cmds, m.analyzeRepo(repoName))
+					ctx, id := m.beginRequest()
+					cmds = append(cmds, m.analyzeRepo(ctx, id, repoName))
 				}
 			case "d":
 				// Delete selected entry
@@ -525,7 +567,7 @@ func (m MainModel) View() string {
 			statusView,
 		)
 	case stateCompareLoading:
-		loadMsg := fmt.Sprintf("📊 Comparing %s vs %s", m.compareInput1, m.compareInput2)
+		loadMsg := fmt.Sprintf("📊 Comparing %s", strings.Join(m.compareRepos, " vs "))
 		statusView := fmt.Sprintf("%s %s...", m.spinner.View(), loadMsg)
 		statusView += "\n\n" + SubtleStyle.Render("Press ESC to cancel")
 
@@ -558,6 +600,9 @@ func (m MainModel) inputView() string {
 
 	if m.err != nil {
 		inputContent += "\n\n" + ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+		if hint := github.Hint(m.err); hint != "" {
+			inputContent += "\n" + SubtleStyle.Render("💡 "+hint)
+		}
 	}
 
 	box := BoxStyle.Render(inputContent)
@@ -575,74 +620,55 @@ func (m MainModel) inputView() string {
 	)
 }
 
-func (m MainModel) analyzeRepo(repoName string) tea.Cmd {
+// beginRequest cancels any analysis still in flight and returns a fresh
+// context for the next one, with the ID to tag its result with. ESC on a
+// loading screen cancels it.
+func (m *MainModel) beginRequest() (context.Context, int) {
+	m.cancelRequests()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelRequest = cancel
+	m.requestID++
+	return ctx, m.requestID
+}
+
+// cancelRequests aborts the in-flight analysis, if any.
+func (m *MainModel) cancelRequests() {
+	if m.cancelRequest != nil {
+		m.cancelRequest()
+		m.cancelRequest = nil
+	}
+}
+
+// analyzeRepo analyzes repoName for the request id, see beginRequest.
+func (m MainModel) analyzeRepo(ctx context.Context, id int, repoName string) tea.Cmd {
 	return func() tea.Msg {
 		parts := strings.Split(repoName, "/")
 		if len(parts) != 2 {
-			return fmt.Errorf("repository must be in owner/repo format")
+			return requestMsg{id: id, msg: fmt.Errorf("repository must be in owner/repo format")}
 		}
-
-		tracker := NewProgressTracker()
-
-		// Stage 1: Fetch repository
-		client := github.NewClient()
-		repo, err := client.GetRepo(parts[0], parts[1])
-		if err != nil {
-			return err
-		}
-		tracker.NextStage()
-
-		// Stage 2: Analyze commits
-		commits, err := client.GetCommits(parts[0], parts[1], 365)
+		pipeline := github.NewPipeline(m.newClient(), github.DefaultWorkers)
+		data, err := pipeline.Fetch(ctx, parts[0], parts[1], m.fetchOpts)
 		if err != nil {
-			return fmt.Errorf("failed to get commits: %w", err)
+			return requestMsg{id: id, msg: err}
 		}
-		tracker.NextStage()
 
-		// Stage 3: Analyze contributors
-		contributors, err := client.GetContributors(parts[0], parts[1])
-		if err != nil {
-			return fmt.Errorf("failed to get contributors: %w", err)
-		}
-		tracker.NextStage()
+		// Failed fetches show up as warnings on the overview
+		return requestMsg{id: id, msg: newAnalysisResult(data, pipeline.Client(), m.healthModel, m.fetchOpts.CommitDays)}
+	}
+}
 
-		// Stage 4: Analyze languages
-		languages, err := client.GetLanguages(parts[0], parts[1])
-		if err != nil {
-			return fmt.Errorf("failed to get languages: %w", err)
-		}
-		fileTree, err := client.GetFileTree(parts[0], parts[1], repo.DefaultBranch)
-		if err != nil {
-			return fmt.Errorf("failed to get file tree: %w", err)
-		}
-		tracker.NextStage()
-
-		// Stage 5: Compute metrics
-		score := analyzer.CalculateHealth(repo, commits)
-		busFactor, busRisk := analyzer.BusFactor(contributors)
-		maturityScore, maturityLevel := analyzer.RepoMaturityScore(repo, len(commits), len(contributors), false)
-		tracker.NextStage()
-
-		// Mark complete
-		tracker.NextStage()
-
-		return AnalysisResult{
-			Repo:          repo,
-			Commits:       commits,
-			Contributors:  contributors,
-			FileTree:      fileTree,
-			Languages:     languages,
-			HealthScore:   score,
-			BusFactor:     busFactor,
-			BusRisk:       busRisk,
-			MaturityScore: maturityScore,
-			MaturityLevel: maturityLevel,
-		}
+// newAnalysisResult computes the metrics for repository data fetched for
+// a window of days, scoring health with model.
+func newAnalysisResult(data *github.RepoData, client *github.Client, model analyzer.HealthModel, days int) AnalysisResult {
+	budget, _ := client.RateBudget()
+	return AnalysisResult{
+		Analysis:  analyzer.Analyze(data, days, model),
+		RateLimit: budget,
 	}
 }
 
 func (m MainModel) checkOwnership() bool {
-	client := github.NewClient()
+	client := m.newClient()
 	user, err := client.GetUser()
 	if err != nil {
 		return false // If we can't get user, assume not owner
@@ -653,28 +679,29 @@ func (m MainModel) checkOwnership() bool {
 }
 
 func (m MainModel) compareInputView() string {
-	var currentInput string
-	var prompt string
-
-	if m.compareStep == 0 {
-		prompt = "📥 ENTER FIRST REPOSITORY"
-		currentInput = m.compareInput1
-	} else {
-		prompt = "📥 ENTER SECOND REPOSITORY"
-		currentInput = m.compareInput2
-	}
-
+	prompt := fmt.Sprintf("📥 ENTER REPOSITORY %d", len(m.compareRepos)+1)
 	inputContent := TitleStyle.Render(prompt) + "\n\n"
 
-	if m.compareStep == 1 {
-		inputContent += SubtleStyle.Render("First: "+m.compareInput1) + "\n\n"
+	for i, repo := range m.compareRepos {
+		inputContent += SubtleStyle.Render(fmt.Sprintf("%d. %s", i+1, repo)) + "\n"
+	}
+	if len(m.compareRepos) > 0 {
+		inputContent += "\n"
 	}
 
-	inputContent += InputStyle.Render("> "+currentInput) + "\n\n"
-	inputContent += SubtleStyle.Render("Format: owner/repo  •  Press Enter to continue  •  ESC to go back")
+	inputContent += InputStyle.Render("> "+m.compareInput) + "\n\n"
+	hint := "Format: owner/repo  •  Enter to add"
+	if len(m.compareRepos) >= 2 {
+		hint += "  •  Enter on an empty line to compare"
+	}
+	hint += "  •  ESC to go back"
+	inputContent += SubtleStyle.Render(hint)
 
 	if m.err != nil {
 		inputContent += "\n\n" + ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+		if hint := github.Hint(m.err); hint != "" {
+			inputContent += "\n" + SubtleStyle.Render("💡 "+hint)
+		}
 	}
 
 	box := BoxStyle.Render(inputContent)
@@ -693,51 +720,103 @@ func (m MainModel) compareInputView() string {
 }
 
 func (m MainModel) compareResultView() string {
-	if m.compareResult == nil || m.compareResult.Repo1.Repo == nil || m.compareResult.Repo2.Repo == nil {
+	if m.compareResult == nil || len(m.compareResult.Repos) < 2 {
 		return "No comparison data"
 	}
 
-	r1 := m.compareResult.Repo1
-	r2 := m.compareResult.Repo2
-
-	header := TitleStyle.Render(fmt.Sprintf("📊 Comparison: %s vs %s", r1.Repo.FullName, r2.Repo.FullName))
-
-	// Build comparison table
-	rows := []string{
-		fmt.Sprintf("%-20s │ %-25s │ %-25s", "Metric", r1.Repo.FullName, r2.Repo.FullName),
-		strings.Repeat("─", 75),
-		fmt.Sprintf("%-20s │ %-25d │ %-25d", "⭐ Stars", r1.Repo.Stars, r2.Repo.Stars),
-		fmt.Sprintf("%-20s │ %-25d │ %-25d", "🍴 Forks", r1.Repo.Forks, r2.Repo.Forks),
-		fmt.Sprintf("%-20s │ %-25d │ %-25d", "📦 Commits (1y)", len(r1.Commits), len(r2.Commits)),
-		fmt.Sprintf("%-20s │ %-25d │ %-25d", "👥 Contributors", len(r1.Contributors), len(r2.Contributors)),
-		fmt.Sprintf("%-20s │ %-25s │ %-25s", "💚 Health Score", fmt.Sprintf("%d", r1.HealthScore), fmt.Sprintf("%d", r2.HealthScore)),
-		fmt.Sprintf("%-20s │ %-25s │ %-25s", "⚠️ Bus Factor", fmt.Sprintf("%d (%s)", r1.BusFactor, r1.BusRisk), fmt.Sprintf("%d (%s)", r2.BusFactor, r2.BusRisk)),
-		fmt.Sprintf("%-20s │ %-25s │ %-25s", "🏗️ Maturity", fmt.Sprintf("%s (%d)", r1.MaturityLevel, r1.MaturityScore), fmt.Sprintf("%s (%d)", r2.MaturityLevel, r2.MaturityScore)),
+	c := m.compareResult.Comparison
+	header := TitleStyle.Render(fmt.Sprintf("📊 Comparison: %s (last %d days)", strings.Join(c.Repos, " vs "), m.compareResult.Repos[0].Days))
+
+	// Column width shrinks with the number of repos to fit the screen
+	width := 25
+	if m.windowWidth > 0 {
+		width = (m.windowWidth - 30) / len(c.Repos)
+	}
+	width = max(12, min(25, width))
+	cell := func(s string) string {
+		return fmt.Sprintf(" │ %-*s", width, truncate(s, width))
+	}
+
+	headerRow := fmt.Sprintf("%-20s", "Metric")
+	for _, repo := range c.Repos {
+		headerRow += cell(repo)
+	}
+	rows := []string{headerRow, strings.Repeat("─", 20+len(c.Repos)*(width+3))}
+
+	// Best values are highlighted; padding comes first so styling keeps the columns aligned
+	for _, r := range c.Metrics {
+		row := fmt.Sprintf("%-20s", r.Metric.Name)
+		for i := range c.Repos {
+			text := "n/a"
+			if r.Warnings[i] != "" {
+				text = "⚠ n/a"
+			} else if r.Known[i] {
+				text = fmt.Sprintf("%s (#%d)", r.Metric.Format(r.Values[i]), r.Ranks[i])
+			}
+			padded := cell(text)
+			if r.IsBest(i) {
+				padded = " │ " + SuccessStyle.Render(padded[len(" │ "):])
+			} else if r.Warnings[i] != "" {
+				padded = " │ " + ErrorStyle.Render(padded[len(" │ "):])
+			}
+			row += padded
+		}
+		rows = append(rows, row)
+	}
+
+	// Info rows from a failed fetch are flagged rather than shown empty
+	info := []struct {
+		name  string
+		tasks []string
+		value func(r AnalysisResult) string
+	}{
+		{"⚠️ Bus Factor Risk", []string{github.TaskContributors}, func(r AnalysisResult) string { return r.BusFactor.Risk }},
+		{"🏗️ Maturity Level", []string{github.TaskCommits, github.TaskContributors, github.TaskReleases}, func(r AnalysisResult) string { return r.MaturityLevel }},
+		{"🗂️ Files", []string{github.TaskTree}, func(r AnalysisResult) string {
+			return fmt.Sprintf("%d (%s)", r.Files.Files, analyzer.FormatBytes(r.Files.TotalBytes))
+		}},
+		{"🔤 Top Extension", []string{github.TaskTree}, func(r AnalysisResult) string { return r.Files.TopExtension() }},
+	}
+	for _, line := range info {
+		row := fmt.Sprintf("%-20s", line.name)
+		for _, r := range m.compareResult.Repos {
+			text := line.value(r)
+			for _, task := range line.tasks {
+				if r.FetchErrors[task] != nil {
+					text = "⚠ n/a"
+				}
+			}
+			row += cell(text)
+		}
+		rows = append(rows, row)
 	}
 
 	tableContent := strings.Join(rows, "\n")
 	tableBox := BoxStyle.Render(tableContent)
 
-	// Verdict
-	var verdict string
-	if r1.MaturityScore > r2.MaturityScore {
-		verdict = fmt.Sprintf("➡️ %s appears more mature and stable.", r1.Repo.FullName)
-	} else if r2.MaturityScore > r1.MaturityScore {
-		verdict = fmt.Sprintf("➡️ %s appears more mature and stable.", r2.Repo.FullName)
-	} else {
-		verdict = "➡️ Both repositories are similarly mature."
+	// Overall ranking and verdict
+	ranking := fmt.Sprintf("🏆 Overall Ranking for %s (%s profile)", c.Profile.Description, c.Profile.Name)
+	for _, o := range c.Overall {
+		ranking += fmt.Sprintf("\n%d. %s (%.1f/100)", o.Rank, o.Repo, o.Score)
+		for _, w := range o.Wins {
+			ranking += "\n   " + SubtleStyle.Render("✔ "+w.String())
+		}
+	}
+	verdictBox := BoxStyle.Render(ranking + "\n\n📌 Verdict\n➡️ " + c.Verdict() + ".")
+
+	// Metrics lost to failed fetches, so that they don't read as zeros
+	sections := []string{header, tableBox}
+	if warnings := c.Warnings(); len(warnings) > 0 {
+		lines := []string{ErrorStyle.Render("⚠️ Missing data (failed fetches)")}
+		for _, w := range warnings {
+			lines = append(lines, truncate(fmt.Sprintf("%s, %s: %s", w.Repo, w.Metric.Name, w.Message), 20+len(c.Repos)*(width+3)))
+		}
+		sections = append(sections, BoxStyle.Render(strings.Join(lines, "\n")))
 	}
-	verdictBox := BoxStyle.Render("📌 Verdict\n" + verdict)
 
-	footer := SubtleStyle.Render("j: export JSON • m: export Markdown • q/ESC: back to menu")
+	footer := SubtleStyle.Render("p: next weight profile • j: export JSON • m: export Markdown • q/ESC: back to menu")
 
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
-		header,
-		tableBox,
-		verdictBox,
-		footer,
-	)
+	content := lipgloss.JoinVertical(lipgloss.Left, append(sections, verdictBox, footer)...)
 
 	if m.windowWidth == 0 {
 		return content
@@ -752,81 +831,45 @@ func (m MainModel) compareResultView() string {
 	)
 }
 
-func (m MainModel) compareRepos(repo1Name, repo2Name string) tea.Cmd {
-	return func() tea.Msg {
-		parts1 := strings.Split(repo1Name, "/")
-		parts2 := strings.Split(repo2Name, "/")
-
-		if len(parts1) != 2 {
-			return fmt.Errorf("first repository must be in owner/repo format")
-		}
-		if len(parts2) != 2 {
-			return fmt.Errorf("second repository must be in owner/repo format")
-		}
-
-		client := github.NewClient()
-
-		// Analyze first repo
-		repo1, err := client.GetRepo(parts1[0], parts1[1])
-		if err != nil {
-			return fmt.Errorf("failed to fetch %s: %w", repo1Name, err)
-		}
-		commits1, _ := client.GetCommits(parts1[0], parts1[1], 365)
-		contributors1, _ := client.GetContributors(parts1[0], parts1[1])
-		languages1, _ := client.GetLanguages(parts1[0], parts1[1])
-		fileTree1, _ := client.GetFileTree(parts1[0], parts1[1], repo1.DefaultBranch)
-		score1 := analyzer.CalculateHealth(repo1, commits1)
-		busFactor1, busRisk1 := analyzer.BusFactor(contributors1)
-		maturityScore1, maturityLevel1 := analyzer.RepoMaturityScore(repo1, len(commits1), len(contributors1), false)
-
-		result1 := AnalysisResult{
-			Repo:          repo1,
-			Commits:       commits1,
-			Contributors:  contributors1,
-			FileTree:      fileTree1,
-			Languages:     languages1,
-			HealthScore:   score1,
-			BusFactor:     busFactor1,
-			BusRisk:       busRisk1,
-			MaturityScore: maturityScore1,
-			MaturityLevel: maturityLevel1,
-		}
+// truncate shortens s to width runes, marking the cut with an ellipsis.
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
 
-		// Analyze second repo
-		repo2, err := client.GetRepo(parts2[0], parts2[1])
-		if err != nil {
-			return fmt.Errorf("failed to fetch %s: %w", repo2Name, err)
+// compareReposCmd compares names for the request id, see beginRequest.
+func (m MainModel) compareReposCmd(ctx context.Context, id int, names []string) tea.Cmd {
+	return func() tea.Msg {
+		for _, name := range names {
+			if len(strings.Split(name, "/")) != 2 {
+				return requestMsg{id: id, msg: fmt.Errorf("%s: repository must be in owner/repo format", name)}
+			}
 		}
-		commits2, _ := client.GetCommits(parts2[0], parts2[1], 365)
-		contributors2, _ := client.GetContributors(parts2[0], parts2[1])
-		languages2, _ := client.GetLanguages(parts2[0], parts2[1])
-		fileTree2, _ := client.GetFileTree(parts2[0], parts2[1], repo2.DefaultBranch)
-		score2 := analyzer.CalculateHealth(repo2, commits2)
-		busFactor2, busRisk2 := analyzer.BusFactor(contributors2)
-		maturityScore2, maturityLevel2 := analyzer.RepoMaturityScore(repo2, len(commits2), len(contributors2), false)
-
-		result2 := AnalysisResult{
-			Repo:          repo2,
-			Commits:       commits2,
-			Contributors:  contributors2,
-			FileTree:      fileTree2,
-			Languages:     languages2,
-			HealthScore:   score2,
-			BusFactor:     busFactor2,
-			BusRisk:       busRisk2,
-			MaturityScore: maturityScore2,
-			MaturityLevel: maturityLevel2,
+		// All repositories are fetched at once on a shared worker pool
+		pipeline := github.NewPipeline(m.newClient(), github.DefaultWorkers)
+		results, errs := pipeline.FetchAll(ctx, names, m.fetchOpts)
+		for i, err := range errs {
+			if err != nil {
+				return requestMsg{id: id, msg: fmt.Errorf("failed to fetch %s: %w", names[i], err)}
+			}
 		}
 
-		return CompareResult{
-			Repo1: result1,
-			Repo2: result2,
+		result := CompareResult{}
+		for _, data := range results {
+			result.Repos = append(result.Repos, newAnalysisResult(data, pipeline.Client(), m.healthModel, m.fetchOpts.CommitDays))
 		}
+		result.Comparison = analyzer.Compare(result.analyses(), analyzer.DefaultWeightProfile())
+		return requestMsg{id: id, msg: result}
 	}
 }
 
-func Run() error {
-	p := tea.NewProgram(NewMainModel(), tea.WithAltScreen())
+// Run starts the dashboard, fetching repositories with fetch, health
+// scored by model and GitHub clients configured by opts.
+func Run(fetch github.FetchOptions, model analyzer.HealthModel, opts ...github.Option) error {
+	p := tea.NewProgram(NewMainModel(fetch, model, opts...), tea.WithAltScreen())
 	_, err := p.Run()
 	return err
 }
@@ -841,6 +884,9 @@ func sanitizeRepoInput(input string) string {
 		if len(parts) == 2 {
 			clean = parts[1]
 		}
+	} else if u, err := url.Parse(clean); err == nil && u.Host != "" {
+		// GitHub Enterprise Server URLs, e.g. https://github.example.com/owner/repo
+		clean = strings.TrimPrefix(u.Path, "/")
 	}
 
 	// Remove trailing slash if present
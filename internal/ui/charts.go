@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -68,3 +69,26 @@ func RenderCommitActivity(data map[string]int, maxDays int) string {
 	}
 	return sb.String()
 }
+
+// RenderWeeklyTrend renders weekly commit counts as a one-line sparkline,
+// oldest week first.
+func RenderWeeklyTrend(weeks []analyzer.WeekCount) string {
+	levels := []rune("▁▂▃▄▅▆▇█")
+
+	max := 0
+	for _, w := range weeks {
+		if w.Commits > max {
+			max = w.Commits
+		}
+	}
+
+	var sb strings.Builder
+	for _, w := range weeks {
+		level := 0
+		if max > 0 {
+			level = w.Commits * (len(levels) - 1) / max
+		}
+		sb.WriteString(barColor(w.Commits, max).Render(string(levels[level])))
+	}
+	return sb.String()
+}
@@ -21,6 +21,9 @@ const (
 	viewActivity
 	viewContributors
 	viewRecruiter
+	viewReleases
+	viewPullRequests
+	viewSecurity
 	viewAPIStatus
 )
 
@@ -150,6 +153,18 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.showHelp = false
 			m.showExport = false
 		case "7":
+			m.currentView = viewReleases
+			m.showHelp = false
+			m.showExport = false
+		case "8":
+			m.currentView = viewPullRequests
+			m.showHelp = false
+			m.showExport = false
+		case "9":
+			m.currentView = viewSecurity
+			m.showHelp = false
+			m.showExport = false
+		case "0":
 			m.currentView = viewAPIStatus
 			m.showHelp = false
 			m.showExport = false
@@ -198,6 +213,12 @@ func (m DashboardModel) View() string {
 		content = m.contributorsView()
 	case viewRecruiter:
 		content = m.recruiterView()
+	case viewReleases:
+		content = m.releasesView()
+	case viewPullRequests:
+		content = m.pullRequestsView()
+	case viewSecurity:
+		content = m.securityView()
 	case viewAPIStatus:
 		content = m.apiStatusView()
 	}
@@ -217,7 +238,7 @@ func (m DashboardModel) View() string {
 
 	// Navigation tabs
 	tabs := m.renderTabs()
-	footer := SubtleStyle.Render("←→/hl: switch view • 1-6: jump to view • e: export • f: file tree • ?: help • q: back")
+	footer := SubtleStyle.Render("←→/hl: switch view • 1-9,0: jump to view • e: export • f: file tree • ?: help • q: back")
 
 	fullContent := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -240,11 +261,11 @@ func (m DashboardModel) View() string {
 }
 
 func (m DashboardModel) renderTabs() string {
-	views := []string{"Overview", "Repo", "Languages", "Activity", "Contributors", "Recruiter", "API"}
+	views := []string{"Overview", "Repo", "Languages", "Activity", "Contributors", "Recruiter", "Releases", "PRs", "Security", "API"}
 	var tabs []string
 
 	for i, name := range views {
-		tab := fmt.Sprintf(" %d:%s ", i+1, name)
+		tab := fmt.Sprintf(" %d:%s ", (i+1)%10, name)
 		if dashboardView(i) == m.currentView {
 			tabs = append(tabs, SelectedStyle.Render(tab))
 		} else {
@@ -263,8 +284,8 @@ func (m DashboardModel) overviewView() string {
 	metrics := fmt.Sprintf(
 		"Health Score: %d\nBus Factor: %d (%s)\nMaturity: %s (%d)",
 		m.data.HealthScore,
-		m.data.BusFactor,
-		m.data.BusRisk,
+		m.data.BusFactor.Count,
+		m.data.BusFactor.Risk,
 		m.data.MaturityLevel,
 		m.data.MaturityScore,
 	)
@@ -274,11 +295,42 @@ func (m DashboardModel) overviewView() string {
 	chart := RenderCommitActivity(activity, 10)
 	chartBox := BoxStyle.Render(chart)
 
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
+	sections := []string{
 		header,
 		lipgloss.JoinHorizontal(lipgloss.Top, metricsBox, chartBox),
-	)
+		BoxStyle.Render(m.healthBreakdown()),
+	}
+	if warnings := m.dataWarnings(); len(warnings) > 0 {
+		lines := append([]string{ErrorStyle.Render("⚠️ Data Warnings")}, warnings...)
+		sections = append(sections, BoxStyle.Render(strings.Join(lines, "\n")))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// dataWarnings lists what limits the analyzed data, one line each.
+func (m DashboardModel) dataWarnings() []string {
+	var lines []string
+	for _, w := range m.data.DataWarnings() {
+		lines = append(lines, "• "+w)
+	}
+	return lines
+}
+
+// healthBreakdown lists the points each health rule awarded and why.
+func (m DashboardModel) healthBreakdown() string {
+	lines := []string{
+		"🧮 Health Score Breakdown",
+		fmt.Sprintf("  %-16s %+6.1f  base score", "base", m.data.Health.Base),
+	}
+	for _, r := range m.data.Health.Rules {
+		mark := "✗"
+		if r.Passed {
+			mark = "✓"
+		}
+		lines = append(lines, fmt.Sprintf("%s %-16s %+6.1f  %s", mark, r.Rule.Name, r.Points, r.Reason))
+	}
+	lines = append(lines, fmt.Sprintf("  %-16s %6d", "total", m.data.Health.Score))
+	return strings.Join(lines, "\n")
 }
 
 func (m DashboardModel) repoView() string {
@@ -305,6 +357,56 @@ func (m DashboardModel) repoView() string {
 		m.data.Repo.HTMLURL,
 	)
 
+	license := "None"
+	if m.data.Repo.License != nil {
+		license = m.data.Repo.License.Name
+	}
+	info += "\n📜 License: " + license
+	if len(m.data.Repo.Topics) > 0 {
+		info += "\n🏷️  Topics: " + strings.Join(m.data.Repo.Topics, ", ")
+	}
+
+	if snap := m.data.Snapshot; snap != nil {
+		info += fmt.Sprintf("\n🐛 Issues / 🔀 PRs open: %d / %d", snap.OpenIssueCount, snap.OpenPRCount)
+		info += fmt.Sprintf("\n🚀 Releases: %d", snap.ReleaseCount)
+		if snap.LatestRelease != nil {
+			info += " (latest " + snap.LatestRelease.TagName + ")"
+		}
+	}
+
+	issues := m.data.Issues
+	if issues.Responded > 0 {
+		info += fmt.Sprintf("\n💬 First Response: median %s, p90 %s",
+			analyzer.FormatDuration(issues.MedianFirstResponse), analyzer.FormatDuration(issues.P90FirstResponse))
+	}
+	if issues.Closed > 0 {
+		info += "\n✅ Time to Close: median " + analyzer.FormatDuration(issues.MedianTimeToClose)
+	}
+	if issues.Open > 0 {
+		var buckets []string
+		for _, b := range issues.Backlog {
+			buckets = append(buckets, fmt.Sprintf("%s: %d", b.Label, b.Count))
+		}
+		info += "\n📚 Backlog Age: " + strings.Join(buckets, " · ")
+	}
+
+	if files := m.data.Files; files.Files > 0 {
+		info += fmt.Sprintf("\n🗂️  Files: %d in %d directories (%s), max depth %d",
+			files.Files, files.Dirs, analyzer.FormatBytes(files.TotalBytes), files.MaxDepth)
+		info += fmt.Sprintf("\n🧪 Tests: %d test / %d source files (ratio %.2f)", files.TestFiles, files.SourceFiles, files.TestRatio)
+		var exts []string
+		for i, g := range files.ByExtension {
+			if i == 5 {
+				break
+			}
+			exts = append(exts, fmt.Sprintf("%s %d", g.Name, g.Files))
+		}
+		info += "\n🔤 Extensions: " + strings.Join(exts, " · ")
+		if len(files.Vendored) > 0 || len(files.Generated) > 0 {
+			info += "\n📦 Vendored / Generated: " + strings.Join(append(append([]string(nil), files.Vendored...), files.Generated...), ", ")
+		}
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(info))
 }
 
@@ -355,9 +457,32 @@ func (m DashboardModel) activityView() string {
 	chart := RenderCommitActivity(activity, 30)
 
 	totalCommits := len(m.data.Commits)
-	stats := fmt.Sprintf("\nTotal Commits (1 year): %d", totalCommits)
+	stats := fmt.Sprintf("\nTotal Commits (last %d days): %d", m.data.Days, totalCommits)
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(chart+stats))
+	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(chart+stats), BoxStyle.Render(m.trendView()))
+}
+
+// trendView shows the weekly commit trend and its change points.
+func (m DashboardModel) trendView() string {
+	trend := m.data.Trend
+	lines := []string{"📉 Weekly Trend: " + trend.String()}
+	if trend.Direction == analyzer.TrendUnknown {
+		lines = append(lines, "Not enough commit history for a trend")
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines,
+		RenderWeeklyTrend(trend.Weeks),
+		fmt.Sprintf("%.1f commits/week on average, %+.0f%% over %d weeks", trend.Mean, trend.Change*100, len(trend.Weeks)),
+	)
+	for _, c := range trend.ChangePoints {
+		mark := "⬆"
+		if c.Drop() {
+			mark = "⬇"
+		}
+		lines = append(lines, fmt.Sprintf("%s Change point %s", mark, c))
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (m DashboardModel) contributorsView() string {
@@ -389,6 +514,22 @@ func (m DashboardModel) contributorsView() string {
 	summary := fmt.Sprintf("\nTotal Contributors: %d", len(m.data.Contributors))
 	lines = append(lines, summary)
 
+	bus := m.data.BusFactor
+	lines = append(lines, fmt.Sprintf("🚌 Bus Factor: %d (%s) by %s, %.0f%% of the work",
+		bus.Count, bus.Risk, bus.Method, bus.Share*100))
+	if len(bus.Members) > 0 {
+		lines = append(lines, "   Key people: "+strings.Join(bus.Members, ", "))
+	}
+	if o := m.data.FileOwnership; o != nil {
+		lines = append(lines, fmt.Sprintf("\n📁 File Ownership: %d files changed by the newest %d commits", o.Files, o.Commits))
+		for i, owner := range o.Owners {
+			if i == 5 {
+				break
+			}
+			lines = append(lines, fmt.Sprintf("   %-20s %d files", owner.Login, owner.Files))
+		}
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(strings.Join(lines, "\n")))
 }
 
@@ -409,33 +550,140 @@ func (m DashboardModel) recruiterView() string {
 		"Repository: %s\n"+
 			"⭐ Stars: %d\n"+
 			"🍴 Forks: %d\n"+
-			"📦 Commits (1y): %d\n"+
+			"📦 Commits (%dd): %d\n"+
 			"👥 Contributors: %d\n"+
 			"🏗️ Maturity: %s (%d)\n"+
 			"⚠️ Bus Factor: %d - %s\n"+
 			"🔥 Activity: %s\n"+
+			"📈 Trend: %s\n"+
+			"🐛 Issue Health: %s\n"+
+			"🔀 PR Health: %s\n"+
 			"💚 Health Score: %d/100",
 		m.data.Repo.FullName,
 		m.data.Repo.Stars,
 		m.data.Repo.Forks,
-		len(m.data.Commits),
+		m.data.Days, len(m.data.Commits),
 		len(m.data.Contributors),
 		m.data.MaturityLevel, m.data.MaturityScore,
-		m.data.BusFactor, m.data.BusRisk,
+		m.data.BusFactor.Count, m.data.BusFactor.Risk,
 		activityLevel,
+		m.data.Trend,
+		m.data.Issues.Health,
+		m.data.PullRequests.Health,
 		m.data.HealthScore,
 	)
 
 	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(summary))
 }
 
+func (m DashboardModel) releasesView() string {
+	header := TitleStyle.Render("🚀 Releases")
+
+	r := m.data.Releases
+	if !r.HasReleases() {
+		return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render("No releases or tags published"))
+	}
+
+	var info string
+	if r.FromTags {
+		info = fmt.Sprintf(
+			"🏷️  Tags: %d\n"+
+				"📌 Latest Version: %s\n\n"+
+				"No GitHub releases are published, so dates\n"+
+				"and cadence are unknown.",
+			r.Count,
+			r.LatestVersion,
+		)
+	} else {
+		cadence := r.Cadence
+		if r.CadenceDays > 0 {
+			cadence += fmt.Sprintf(" (every %.0f days)", r.CadenceDays)
+		}
+		info = fmt.Sprintf(
+			"📦 Releases: %d\n"+
+				"📌 Latest Version: %s\n"+
+				"📅 Published: %s\n"+
+				"⏱️  Days Since Last Release: %d\n"+
+				"🔁 Cadence: %s",
+			r.Count,
+			r.LatestVersion,
+			r.LatestAt.Format("2006-01-02"),
+			r.DaysSinceLast,
+			cadence,
+		)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(info))
+}
+
+func (m DashboardModel) pullRequestsView() string {
+	header := TitleStyle.Render("🔀 Pull Requests")
+
+	pr := m.data.PullRequests
+	if pr.Total == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render("No pull requests in the analysis window"))
+	}
+
+	review := "no reviews in sample"
+	if pr.ReviewedSample > 0 {
+		review = fmt.Sprintf("%s (%d reviewed PRs)", analyzer.FormatDuration(pr.MedianTimeToFirstReview), pr.ReviewedSample)
+	}
+
+	info := fmt.Sprintf(
+		"📬 Total: %d (open %d, merged %d, closed unmerged %d)\n"+
+			"✅ Merged vs Closed: %.0f%%\n"+
+			"⏱️  Median Time to Merge: %s\n"+
+			"👀 Median Time to First Review: %s\n"+
+			"🤝 External Acceptance: %.0f%% (%d of %d closed)\n\n"+
+			"Health: %s",
+		pr.Total, pr.Open, pr.Merged, pr.ClosedUnmerged,
+		pr.MergeRatio*100,
+		analyzer.FormatDuration(pr.MedianTimeToMerge),
+		review,
+		pr.ExternalAcceptance*100, pr.ExternalMerged, pr.ExternalClosed,
+		pr.Health,
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(info))
+}
+
+func (m DashboardModel) securityView() string {
+	header := TitleStyle.Render("🔒 Security Hygiene")
+
+	sec := m.data.Security
+	lines := []string{"Findings: " + sec.Summary()}
+	for _, check := range sec.Passed {
+		lines = append(lines, "✓ "+check.Name)
+	}
+	for _, check := range sec.Skipped {
+		lines = append(lines, "? "+check.Name+" (skipped, "+check.Reason+")")
+	}
+
+	for _, f := range sec.Findings {
+		mark := "🟡"
+		switch f.Severity {
+		case analyzer.SeverityHigh:
+			mark = "🔴"
+		case analyzer.SeverityLow:
+			mark = "⚪"
+		}
+		title := fmt.Sprintf("%s %-6s %s", mark, f.Severity, f.Title)
+		if f.Path != "" {
+			title += " (" + f.Path + ")"
+		}
+		lines = append(lines, "", title, "   → "+f.Remediation)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(strings.Join(lines, "\n")))
+}
+
 func (m DashboardModel) helpView() string {
 	header := TitleStyle.Render("❓ Keyboard Shortcuts")
 
 	help := `
 Dashboard Navigation:
   ←/→ or h/l    Switch between views
-  1-7           Jump to specific view
+  1-9, 0        Jump to specific view
   
 Views:
   1  Overview     - Health, Bus Factor, Maturity
@@ -444,7 +692,10 @@ Views:
   4  Activity     - Commit activity chart
   5  Contributors - Top contributors
   6  Recruiter    - Summary for recruiters
-  7  API Status   - GitHub API rate limits
+  7  Releases     - Release count and cadence
+  8  PRs          - Merge time, reviews, acceptance
+  9  Security     - Repository security hygiene
+  0  API Status   - GitHub API rate limits
 
 Actions:
   e             Toggle export menu
@@ -469,32 +720,47 @@ Actions:
 func (m DashboardModel) apiStatusView() string {
 	header := TitleStyle.Render("🔐 GitHub API Status")
 
-	// Check if authenticated
+	budget := m.data.RateLimit
+
 	mode := "Unauthenticated (60 req/hour)"
-	if m.data.Repo != nil && m.data.Repo.Private {
-		mode = "Authenticated (5000 req/hour)"
-	} else {
-		// Simple check - if we got detailed data, likely authenticated
-		if len(m.data.Contributors) > 30 {
-			mode = "Authenticated (5000 req/hour)"
-		}
+	if budget.Limit > 60 {
+		mode = fmt.Sprintf("Authenticated (%d req/hour)", budget.Limit)
+	}
+
+	requests := "Requests Left: unknown"
+	if budget.Known() {
+		requests = fmt.Sprintf(
+			"Requests Left: %d / %d (%d used)\n"+
+				"Resets At: %s (as of %s)",
+			budget.Remaining,
+			budget.Limit,
+			budget.Used,
+			budget.Reset.Format("15:04"),
+			budget.UpdatedAt.Format("15:04:05"),
+		)
 	}
 
 	info := fmt.Sprintf(
-		"Mode: %s\n\n"+
+		"Mode: %s\n"+
+			"%s\n\n"+
 			"Data Fetched:\n"+
 			"  • Repository info: ✓\n"+
-			"  • Commits (1 year): %d\n"+
+			"  • Commits (last %d days): %d\n"+
 			"  • Contributors: %d\n"+
 			"  • Languages: %d\n"+
-			"  • File tree: %d entries\n\n"+
+			"  • File tree: %d entries\n"+
+			"  • Releases: %d\n"+
+			"  • Pull requests: %d\n\n"+
 			"Tip: Set GITHUB_TOKEN env variable\n"+
 			"for higher rate limits (5000/hour)",
 		mode,
-		len(m.data.Commits),
+		requests,
+		m.data.Days, len(m.data.Commits),
 		len(m.data.Contributors),
 		len(m.data.Languages),
 		len(m.data.FileTree),
+		m.data.Releases.Count,
+		m.data.PullRequests.Total,
 	)
 
 	return lipgloss.JoinVertical(lipgloss.Left, header, BoxStyle.Render(info))
@@ -1,22 +1,39 @@
 package ui
 
-import "github.com/agnivo988/Repo-lyzer/internal/github"
+import (
+	"github.com/agnivo988/Repo-lyzer/internal/analyzer"
+	"github.com/agnivo988/Repo-lyzer/internal/github"
+)
 
 type AnalysisResult struct {
-	Repo          *github.Repo
-	Commits       []github.Commit
-	Contributors  []github.Contributor
-	FileTree      []github.TreeEntry
-	Languages     map[string]int
-	HealthScore   int
-	BusFactor     int
-	BusRisk       string
-	MaturityScore int
-	MaturityLevel string
+	analyzer.Analysis
+	RateLimit github.RateBudget // API budget left after the analysis
 }
 
-// CompareResult holds analysis data for two repositories
+// CompareResult holds analysis data for the compared repositories
 type CompareResult struct {
-	Repo1 AnalysisResult
-	Repo2 AnalysisResult
+	Repos      []AnalysisResult
+	Comparison analyzer.Comparison // Rankings, indexed like Repos
+}
+
+// analyses returns the analyses of the compared repositories.
+func (c CompareResult) analyses() []analyzer.Analysis {
+	out := make([]analyzer.Analysis, len(c.Repos))
+	for i, r := range c.Repos {
+		out[i] = r.Analysis
+	}
+	return out
+}
+
+// nextProfile ranks the comparison again with the built-in weight profile
+// after the current one.
+func (c CompareResult) nextProfile() CompareResult {
+	next := 0
+	for i, p := range analyzer.WeightProfiles {
+		if p.Name == c.Comparison.Profile.Name {
+			next = (i + 1) % len(analyzer.WeightProfiles)
+		}
+	}
+	c.Comparison = analyzer.Compare(c.analyses(), analyzer.WeightProfiles[next])
+	return c
 }
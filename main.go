@@ -3,10 +3,19 @@
 // into code health, contributor activity, and project maturity.
 package main
 
-import "github.com/agnivo988/Repo-lyzer/cmd"
+import (
+	"os"
 
-// main initializes and runs the Repo-lyzer application.
-// It starts the interactive menu interface for repository analysis.
+	"github.com/agnivo988/Repo-lyzer/cmd"
+)
+
+// main starts the interactive menu interface when run without arguments
+// and the command-line interface otherwise, e.g. `repo-lyzer analyze
+// owner/repo --format json` in CI.
 func main() {
+	if len(os.Args) > 1 {
+		cmd.Execute()
+		return
+	}
 	cmd.RunMenu()
 }